@@ -24,14 +24,14 @@ func GetBoardValue[T int | uint | byte | rune | bool](x, y int, board [][]T) T {
 	return board[y][x]
 }
 
-// find
-func FindValue[T int | uint | byte | rune | bool](board [][]T, c T) (x, y int) {
+// FindValue finds the first x,y of c in the board, ok is false if it isn't found
+func FindValue[T int | uint | byte | rune | bool](board [][]T, c T) (x, y int, ok bool) {
 	for y := 0; y < len(board); y++ {
 		for x := 0; x < len(board[y]); x++ {
 			if board[y][x] == c {
-				return x, y
+				return x, y, true
 			}
 		}
 	}
-	return 0, 0
+	return 0, 0, false
 }
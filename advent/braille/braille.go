@@ -1,11 +1,12 @@
-package advent
+// Package braille renders pixel grids as unicode braille characters, 2x4 pixels per character
+package braille
 
 import (
 	"strconv"
 	"strings"
 )
 
-// BrailleColorFunc decides how to color a single Braille cell.
+// ColorFunc decides how to color a single Braille cell.
 //
 // cellX, cellY: braille cell coordinates (0-based) in the output grid
 // dots: 8 bytes, one per dot (1..8), 0 = off, non-zero = whatever was in grid
@@ -14,12 +15,12 @@ import (
 //
 //	fg:  0–255 for 256-color foreground, or -1 for "no fg color"
 //	bg:  0–255 for 256-color background, or -1 for "no bg color"
-//	ok:  if false, RenderBrailleWithColor will render without any color
-type BrailleColorFunc func(cellX, cellY int, dots [8]byte) (fg, bg int, ok bool)
+//	ok:  if false, RenderWithColor will render without any color
+type ColorFunc func(cellX, cellY int, dots [8]byte) (fg, bg int, ok bool)
 
-// RenderBraille renders a grid of bytes as a string of Unicode braille cells.
+// Render renders a grid of bytes as a string of Unicode braille cells.
 // grid[y][x] == 0 => pixel off, != 0 => pixel on.
-func RenderBraille(grid [][]byte) string {
+func Render(grid [][]byte) string {
 	if len(grid) == 0 {
 		return ""
 	}
@@ -87,12 +88,12 @@ func RenderBraille(grid [][]byte) string {
 	return b.String()
 }
 
-// RenderBrailleWithColor renders a grid of bytes as a string of Unicode braille
+// RenderWithColor renders a grid of bytes as a string of Unicode braille
 // cells and uses colorFn to optionally wrap each cell in ANSI color codes.
 //
 // grid[y][x] == 0 => pixel off
 // grid[y][x] != 0 => pixel "on" with that value (used only by colorFn)
-func RenderBrailleWithColor(grid [][]byte, colorFn BrailleColorFunc) string {
+func RenderWithColor(grid [][]byte, colorFn ColorFunc) string {
 	if len(grid) == 0 {
 		return ""
 	}
@@ -224,12 +225,7 @@ func RenderBrailleWithColor(grid [][]byte, colorFn BrailleColorFunc) string {
 // 5–6     -> yellow
 // 7–8     -> red
 func DensityColor(cellX, cellY int, dots [8]byte) (fg, bg int, ok bool) {
-	count := 0
-	for _, v := range dots {
-		if v != 0 {
-			count++
-		}
-	}
+	count := DotCount(dots)
 	if count == 0 {
 		return 240, -1, true // dark gray, no background
 	}
@@ -245,3 +241,64 @@ func DensityColor(cellX, cellY int, dots [8]byte) (fg, bg int, ok bool) {
 		return 196, -1, true // red
 	}
 }
+
+// CellFunc renders a single braille cell. r is the braille rune for the cell and dots holds the grid value
+// under each of its 8 dots, 0 for off.
+type CellFunc func(cellX, cellY int, r rune, dots [8]byte) string
+
+// RenderFunc renders a grid of bytes one braille cell at a time, letting cellFn decide how each cell is drawn,
+// i.e. to style it or swap it for a plain character.
+func RenderFunc(grid [][]byte, cellFn CellFunc) string {
+	if len(grid) == 0 {
+		return ""
+	}
+
+	height := len(grid)
+	width := 0
+	for _, row := range grid {
+		width = max(width, len(row))
+	}
+
+	valueAt := func(px, py int) byte {
+		if py < 0 || py >= height || px < 0 || px >= len(grid[py]) {
+			return 0
+		}
+		return grid[py][px]
+	}
+
+	// the pixel offsets of dots 1 to 8 in a cell
+	offsets := [8][2]int{{0, 0}, {0, 1}, {0, 2}, {1, 0}, {1, 1}, {1, 2}, {0, 3}, {1, 3}}
+
+	var b strings.Builder
+	for y := 0; y < height; y += 4 {
+		for x := 0; x < width; x += 2 {
+			var mask uint8
+			var dots [8]byte
+			for i, o := range offsets {
+				if v := valueAt(x+o[0], y+o[1]); v != 0 {
+					mask |= 1 << i
+					dots[i] = v
+				}
+			}
+			b.WriteString(cellFn(x/2, y/4, rune(0x2800+int(mask)), dots))
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// DotCount returns how many of a cell's dots are on
+func DotCount(dots [8]byte) int {
+	count := 0
+	for _, v := range dots {
+		if v != 0 {
+			count++
+		}
+	}
+	return count
+}
+
+// Shade is a plain ascii stand-in for a braille cell, darker the more dots are on
+func Shade(dots [8]byte) rune {
+	return []rune(" ..::++##")[DotCount(dots)]
+}
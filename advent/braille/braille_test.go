@@ -1,12 +1,12 @@
-package advent_test
+package braille_test
 
 import (
 	"testing"
 
-	"github.com/sirgwain/advent-of-code-2025/advent"
+	"github.com/sirgwain/advent-of-code-2025/advent/braille"
 )
 
-func TestRenderBraille(t *testing.T) {
+func TestRender(t *testing.T) {
 	tests := []struct {
 		name string
 		grid [][]byte
@@ -94,13 +94,36 @@ func TestRenderBraille(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := advent.RenderBraille(tt.grid)
+			got := braille.Render(tt.grid)
 
 			t.Logf("got:\n%s", got)
 
 			if got != tt.want {
-				t.Errorf("RenderBraille() = %q, want %q", got, tt.want)
+				t.Errorf("Render() = %q, want %q", got, tt.want)
 			}
 		})
 	}
 }
+
+func TestRenderFunc(t *testing.T) {
+	grid := [][]byte{
+		{1, 0, 0, 1},
+		{0, 1, 1, 0},
+		{0, 0, 0, 0},
+		{0, 0, 0, 0},
+		{1, 1},
+		{1, 1},
+		{1, 1},
+		{1, 1},
+	}
+
+	// drawing each cell as its rune matches Render
+	if got, want := braille.RenderFunc(grid, func(_, _ int, r rune, _ [8]byte) string { return string(r) }), braille.Render(grid); got != want {
+		t.Errorf("RenderFunc() = %q, want %q", got, want)
+	}
+
+	want := "..\n# \n"
+	if got := braille.RenderFunc(grid, func(_, _ int, _ rune, dots [8]byte) string { return string(braille.Shade(dots)) }); got != want {
+		t.Errorf("RenderFunc() with Shade = %q, want %q", got, want)
+	}
+}
@@ -1,10 +1,11 @@
 package advent
 
 import (
-	"bufio"
 	"fmt"
-	"os"
+	"io"
 	"strconv"
+
+	"github.com/sirgwain/advent-of-code-2025/advent/parse"
 )
 
 type Day1 struct {
@@ -33,6 +34,7 @@ func (d *Day1) Run(updates chan<- DayUpdate) error {
 			updates <- DayUpdate{
 				View:     d.view(),
 				Solution: d.viewSolution(),
+				Answer:   d.answer(),
 				Done:     d.done(),
 			}
 		}
@@ -41,45 +43,58 @@ func (d *Day1) Run(updates chan<- DayUpdate) error {
 	updates <- DayUpdate{
 		View:     d.view(),
 		Solution: d.viewSolution(),
+		Answer:   d.answer(),
 		Done:     d.done(),
 	}
 
 	return nil
 }
 
-// Init loads in the input from the file and initializes the Day
-func (d *Day1) Init(filename string, options *Options) (err error) {
+// day1Rotation is a line of input, like L68
+type day1Rotation struct {
+	Dir    day1Direction
+	Clicks int
+}
+
+// day1Direction is the L or R of a rotation, as the sign of the clicks
+type day1Direction byte
+
+func (dir *day1Direction) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "L", "R":
+		*dir = day1Direction(text[0])
+		return nil
+	}
+	return fmt.Errorf("direction must be L or R")
+}
+
+var day1Format = parse.MustCompile[day1Rotation]("{Dir:c}{Clicks}")
+
+// Init loads in the input and initializes the Day
+func (d *Day1) Init(r io.Reader, options *Options) (err error) {
 	d.Options = options
+	defer func() { err = asParseError(d.Day(), err) }()
 	// dial starts at 50
 	d.dial = 50
 
-	file, err := os.Open(filename)
+	in, err := readInput(r, d.InputName)
 	if err != nil {
-		return fmt.Errorf("error opening file: %w", err)
+		return err
+	}
+	rotations, err := day1Format.Records(in)
+	if err != nil {
+		return err
 	}
-	defer file.Close()
-
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := scanner.Text()
-
-		num, err := strconv.Atoi(line[1:])
-		if err != nil {
-			return fmt.Errorf("error parsing number on line: %s", line)
-		}
 
-		if line[0] == 'L' {
-			d.input = append(d.input, -num)
+	for _, rot := range rotations {
+		if rot.Dir == 'L' {
+			d.input = append(d.input, -rot.Clicks)
 		} else {
-			d.input = append(d.input, num)
+			d.input = append(d.input, rot.Clicks)
 		}
 	}
 
-	if err := scanner.Err(); err != nil {
-		return fmt.Errorf("error reading file: %w", err)
-	}
-
-	return err
+	return nil
 }
 
 func (d *Day1) Progress() bool {
@@ -134,7 +149,11 @@ func (d *Day1) view() string {
 
 func (d *Day1) viewSolution() string {
 	return fmt.Sprintf("solution1: %s solution2: %s",
-		solutionStyle.Render(strconv.Itoa(d.solution1)),
-		solutionStyle.Render(strconv.Itoa(d.solution2)),
+		d.Theme.Solution.Render(strconv.Itoa(d.solution1)),
+		d.Theme.Solution.Render(strconv.Itoa(d.solution2)),
 	)
 }
+
+func (d *Day1) answer() Answer {
+	return Answer{Part1: int64(d.solution1), Part2: int64(d.solution2)}
+}
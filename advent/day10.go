@@ -2,32 +2,37 @@ package advent
 
 import (
 	"fmt"
+	"io"
 	"math"
 	"math/bits"
-	"os"
-	"regexp"
 	"strconv"
 	"strings"
 
-	"github.com/charmbracelet/lipgloss"
-	"github.com/sirgwain/advent-of-code-2025/advent/color"
-)
-
-var (
-	renderedLightOn  = lipgloss.NewStyle().Foreground(color.BrightGreen82).Render(" ● ")
-	renderedLightOff = lipgloss.NewStyle().Foreground(color.Gray8).Render(" ○ ")
-
-	renderedButtonOn  = lipgloss.NewStyle().Foreground(color.BrightRed124).Render(" ■ ")
-	renderedButtonOff = lipgloss.NewStyle().Foreground(color.Gray8).Render(" □ ")
+	"github.com/sirgwain/advent-of-code-2025/advent/linalg"
+	"github.com/sirgwain/advent-of-code-2025/advent/memo"
+	"github.com/sirgwain/advent-of-code-2025/advent/parse"
 )
 
 type Day10 struct {
 	*Options
 	input     []day10Light
+	halving   bool // find joltage presses with the reference solveSingle instead of the ILP
 	solution1 int
 	solution2 int
+
+	// prerender some styled characters
+	renderedLightOn   string
+	renderedLightOff  string
+	renderedButtonOn  string
+	renderedButtonOff string
 }
 
+// day10MaxCounters is the most joltage counters a machine can have, so joltages fit in a fixed size key
+const day10MaxCounters = 16
+
+// day10Key is a joltage vector that can be used as a map key
+type day10Key = [day10MaxCounters]int
+
 // [.##.] (3) (1,3) (2) (2,3) (0,2) (0,1) {3,5,4,7}
 type day10Light struct {
 	light         uint     // bitmask of the light 0b0110
@@ -36,74 +41,141 @@ type day10Light struct {
 	coeffs        [][]int  // {1,1,1,1,1,0}, // (0,1,2,3,4)
 	buttonStrs    []string // button bitmasks, i.e. 0b0001, 0b0101, etc...
 	joltage       []int    // joltage requirements
+	presses       []int    // how many times part2 presses each button
+}
+
+// day10Record is a line of input, the lights, the buttons and the joltage
+type day10Record struct {
+	Lights  day10Lights
+	Buttons []day10Button
+	Joltage []int
+}
+
+var day10Format = parse.MustCompile[day10Record]("[{Lights}] {Buttons: } {{{Joltage:,}}}")
+
+// day10Lights is a light pattern like .##. as a bitmask, 0b0110
+type day10Lights struct {
+	mask  uint
+	count int // how many lights are in the pattern
+}
+
+func (lights *day10Lights) UnmarshalText(text []byte) error {
+	if len(text) > bits.UintSize {
+		return fmt.Errorf("more than %d lights", bits.UintSize)
+	}
+	var mask uint
+	for i, state := range text {
+		switch state {
+		case '#':
+			mask |= 1 << i
+		case '.':
+		default:
+			// point at the light rather than the whole pattern
+			return &parse.Error{Column: i + 1, Token: string(state), Err: fmt.Errorf("lights must be . or #")}
+		}
+	}
+	*lights = day10Lights{mask: mask, count: len(text)}
+	return nil
+}
+
+// String is the light pattern, .##.
+func (lights day10Lights) String() string {
+	var sb strings.Builder
+	for i := range lights.count {
+		if lights.mask&(1<<i) != 0 {
+			sb.WriteByte('#')
+		} else {
+			sb.WriteByte('.')
+		}
+	}
+	return sb.String()
+}
+
+// day10Button is a button like (1,3), with the lights it toggles
+type day10Button struct {
+	text    string
+	indices []int
+}
+
+func (b *day10Button) UnmarshalText(text []byte) error {
+	s := string(text)
+	if !strings.HasPrefix(s, "(") || !strings.HasSuffix(s, ")") {
+		return fmt.Errorf("buttons must be in parens")
+	}
+	b.text = s
+	b.indices = nil
+	for lightStr := range strings.SplitSeq(s[1:len(s)-1], ",") {
+		n, err := strconv.Atoi(lightStr)
+		if err != nil || n < 0 || n >= bits.UintSize {
+			return fmt.Errorf("buttons must be light numbers from 0 to %d", bits.UintSize-1)
+		}
+		b.indices = append(b.indices, n)
+	}
+	return nil
 }
 
 func (d *Day10) Day() int {
 	return 10
 }
 
-// Init loads in the input from the file and initializes the Day
-func (d *Day10) Init(filename string, options *Options) (err error) {
+// Init loads in the input and initializes the Day
+func (d *Day10) Init(r io.Reader, options *Options) (err error) {
 	d.Options = options
+	defer func() { err = asParseError(d.Day(), err) }()
+	d.renderedLightOn = d.Theme.On.Render(d.Theme.Glyph(" ● ", " # "))
+	d.renderedLightOff = d.Theme.Off.Render(d.Theme.Glyph(" ○ ", " . "))
+	d.renderedButtonOn = d.Theme.Incorrect.Render(d.Theme.Glyph(" ■ ", " X "))
+	d.renderedButtonOff = d.Theme.Off.Render(d.Theme.Glyph(" □ ", " _ "))
+
+	// part2 solves an integer linear program, unless the params ask for the reference solver
+	switch joltage := d.Param("joltage", "ilp"); joltage {
+	case "ilp":
+		d.halving = false
+	case "halving":
+		d.halving = true
+	default:
+		return fmt.Errorf("unknown joltage param %q, expected ilp or halving", joltage)
+	}
+
 	// format:
 	// [.##.] (3) (1,3) (2) (2,3) (0,2) (0,1) {3,5,4,7}
 	// [...#.] (0,2,3,4) (2,3) (0,4) (0,1,2) (1,2,3,4) {7,5,12,7,2}
 	// [.###.#] (0,1,2,3,4) (0,3,4) (0,1,2,4,5) (1,2) {10,11,11,5,10,5}
 
-	content, err := os.ReadFile(filename)
+	in, err := readInput(r, d.InputName)
 	if err != nil {
 		return err
 	}
 
-	var lineRe = regexp.MustCompile(
-		`^\s*\[([.#]+)\]\s*((?:\([0-9,]+\)\s*)+)\{([0-9,]+)\}\s*$`)
-
-	lines := strings.Split(string(content), ("\n"))
-	d.input = make([]day10Light, 0, len(lines))
-	for _, line := range lines {
-		m := lineRe.FindStringSubmatch(line)
-		if m == nil {
-			return fmt.Errorf("line doesn't match Day10 format: %q", line)
+	d.input = make([]day10Light, 0, len(in.Lines))
+	for _, line := range in.Lines {
+		record, err := day10Format.ParseLine(line)
+		if err != nil {
+			return err
 		}
 
-		lights := m[1]       // e.g. ".##."
-		buttonsChunk := m[2] // e.g. "(3) (1,3) (2)"
-		joltageChunk := m[3] // e.g. "3,5,4,7"
-
-		var l day10Light
-		// Parse indicator mask
-		var mask uint
-		for i, state := range lights {
-			if state == '#' {
-				mask |= 1 << i
-			}
+		// each light has a joltage counter, the view draws the lights from the counters
+		if len(record.Joltage) != record.Lights.count {
+			return line.Errorf(strings.Index(line.Text, "{")+1, "%d joltage counters for %d lights", len(record.Joltage), record.Lights.count)
 		}
-		l.light = mask
 
-		for _, btnStr := range strings.Split(strings.TrimSpace(buttonsChunk), " ") {
+		l := day10Light{light: record.Lights.mask, joltage: record.Joltage}
+		for _, button := range record.Buttons {
 			var mask uint
-
-			buttons := []int{}
-			for _, lightStr := range strings.Split(btnStr[1:len(btnStr)-1], ",") {
-				n, err := strconv.Atoi(lightStr)
-				if err != nil {
-					return err
-				}
+			for _, n := range button.indices {
 				// add 0b0...n...0 as a button
 				mask |= 1 << n
-				buttons = append(buttons, n)
 			}
 			l.buttons = append(l.buttons, mask)
-			l.buttonIndices = append(l.buttonIndices, buttons)
-			l.buttonStrs = append(l.buttonStrs, btnStr)
+			l.buttonIndices = append(l.buttonIndices, button.indices)
+			l.buttonStrs = append(l.buttonStrs, button.text)
 		}
 
-		for _, joltageStr := range strings.Split(joltageChunk, ",") {
-			n, err := strconv.Atoi(joltageStr)
-			if err != nil {
-				return err
-			}
-			l.joltage = append(l.joltage, n)
+		if len(l.buttons) > linalg.MaxGF2Columns {
+			return line.Errorf(0, "%d buttons, more than the max of %d", len(l.buttons), linalg.MaxGF2Columns)
+		}
+		if len(l.joltage) > day10MaxCounters {
+			return line.Errorf(0, "%d joltage counters, more than the max of %d", len(l.joltage), day10MaxCounters)
 		}
 
 		// Build coeffs: indicator vectors (len == len(goal) == len(joltage))
@@ -112,9 +184,9 @@ func (d *Day10) Init(filename string, options *Options) (err error) {
 		for bi, idxs := range l.buttonIndices {
 			indicator := make([]int, numVars)
 			for _, idx := range idxs {
-				if idx < 0 || idx >= numVars {
-					return fmt.Errorf("line %s button %d index %d out of range [0,%d) token=%q",
-						line, bi, idx, numVars, l.buttonStrs[bi],
+				if idx >= numVars {
+					return line.Errorf(strings.Index(line.Text, l.buttonStrs[bi])+1, "button %d index %d out of range [0,%d): %q",
+						bi, idx, numVars, l.buttonStrs[bi],
 					)
 				}
 				indicator[idx] = 1
@@ -129,102 +201,96 @@ func (d *Day10) Init(filename string, options *Options) (err error) {
 }
 
 func (d *Day10) Run(updates chan<- DayUpdate) error {
-	d.part1()
-
-	// d.part2()
+	if err := d.part1(updates); err != nil {
+		return err
+	}
 
-	// found this solution on reddit: https://www.reddit.com/r/adventofcode/comments/1pk87hl/2025_day_10_part_2_bifurcate_your_way_to_victory/
-	// My attempt to implement it was unsuccessful
-	for i, l := range d.input {
-		sub := solveSingle(l.coeffs, l.joltage)
-		fmt.Printf("Line %d/%d: joltage: %v, answer %d\n", i+1, len(d.input), l.joltage, sub)
-		d.solution2 += sub
+	if err := d.part2(updates); err != nil {
+		return err
 	}
 
 	updates <- DayUpdate{
 		View:     d.view(),
 		Solution: d.viewSolution(),
+		Answer:   d.answer(),
 		Done:     true,
 	}
 	return nil
 }
 
-func (d *Day10) part1() {
-	for _, l := range d.input {
+func (d *Day10) part1(updates chan<- DayUpdate) error {
+	for i, l := range d.input {
 
-		minPresses, buttons := d.minPressesToToggle(l.light, l.buttons)
+		minPresses, buttons, err := d.minPressesToToggle(l.light, l.buttons)
+		if err != nil {
+			return fmt.Errorf("line %d lights %s: %w", i+1, day10Lights{mask: l.light, count: len(l.joltage)}, err)
+		}
+		d.solution1 += minPresses
 
 		if !d.Quiet {
-			fmt.Printf("%s %s %d presses\n", d.viewLight(l.light, len(l.joltage)), d.viewButtons(buttons, l.buttonIndices), minPresses)
-			d.solution1 += minPresses
+			updates <- DayUpdate{
+				View:     fmt.Sprintf("%s %s %d presses", d.viewLight(l.light, len(l.joltage)), d.viewButtons(buttons, l.buttonIndices), minPresses),
+				Solution: d.viewSolution(),
+				Answer:   d.answer(),
+				Done:     false,
+			}
 		}
 	}
-
+	return nil
 }
 
-// part2 attempts to recursively reduce each joltage to 0 by pressing buttons to make the joltage even, then dividing by 2, then making even, etc
-// it doesn't work on all inputs, and it panics if there is an odd pattern that can't be made even
-// to fix, it needs to try every odd -> even pattern in a recursive loop, instead of just picking the first one it finds
-func (d *Day10) part2() {
-	for _, l := range d.input {
+// part2 finds the fewest presses to reach each machine's joltage. Each press adds 1 to some of the counters, so
+// this is the non-negative integer solution to coeffs * presses = joltage with the smallest sum.
+func (d *Day10) part2(updates chan<- DayUpdate) error {
+	for i := range d.input {
+		l := &d.input[i]
+		total := 0
+		if d.halving {
+			// the reference only finds the total, so there are no presses to show
+			total, _ = solveSingle(l.coeffs, l.joltage)
+		} else {
+			presses, err := linalg.MinSumSolution(l.coeffs, l.joltage)
+			if err != nil {
+				return fmt.Errorf("line %d joltage %v: %w", i+1, l.joltage, err)
+			}
+			l.presses = presses
+			for _, n := range presses {
+				total += n
+			}
+		}
+		d.solution2 += total
 
-		joltage := make([]int, len(l.joltage))
-		copy(joltage, l.joltage)
-		presses := d.reduceVoltageToZero(&l)
 		if !d.Quiet {
-			fmt.Printf("%v %d presses\n",
-				joltage,
-				presses,
-			)
+			updates <- DayUpdate{
+				View:     fmt.Sprintf("%v %s%d presses", l.joltage, d.viewPresses(l), total),
+				Solution: d.viewSolution(),
+				Answer:   d.answer(),
+				Done:     false,
+			}
 		}
-
-		d.solution2 += presses
 	}
+	return nil
 }
 
-// reduceVoltageToZero gradually presses buttons/divides voltage to try and get to zero
-func (d *Day10) reduceVoltageToZero(l *day10Light) int {
-	if l.isZeroJoltage() {
-		// no more presses require
-		return 0
+// minPressesToToggle finds the minimum presses to toggle lights into a configuration. Pressing a button twice
+// undoes it, so this is solving buttons * presses = desired over GF(2). Every solution is one particular set of
+// presses plus some combination of the null space, so only those combinations need checking instead of every
+// subset of buttons. It returns an error wrapping linalg.ErrNoSolution if no presses make the configuration.
+func (d *Day10) minPressesToToggle(desired uint, buttons []uint) (presses int, bestButtons uint, err error) {
+	columns := make([]uint64, len(buttons))
+	for i, b := range buttons {
+		columns[i] = uint64(b)
 	}
-
-	joltage := make([]int, len(l.joltage))
-	copy(joltage, l.joltage)
-
-	oddJoltageBits := l.oddJoltageBits()
-	if oddJoltageBits != 0 {
-		// turn it odd
-		minPresses, buttons := d.minPressesToToggle(l.oddJoltageBits(), l.buttons)
-		l.reduceJoltage(buttons)
-
-		if !d.Quiet {
-			fmt.Printf("%v -> %v %d presses to make even %s\n",
-				joltage,
-				l.joltage,
-				minPresses,
-				d.viewButtons(buttons, l.buttonIndices),
-			)
-		}
-
-		// keep track of these presses
-		return minPresses + d.reduceVoltageToZero(l)
-	} else {
-		// divide voltage by 2
-		l.halveJoltage()
-		if !d.Quiet {
-			fmt.Printf("%v -> %v halved joltage\n",
-				joltage,
-				l.joltage,
-			)
-		}
-
-		return 2 * d.reduceVoltageToZero(l)
+	s, err := linalg.SolveGF2(columns, uint64(desired))
+	if err != nil {
+		return 0, 0, err
 	}
+	best := s.MinWeight()
+	return bits.OnesCount64(best), uint(best), nil
 }
 
-// minPressesToToggle finds the minimum presses to toggle lights into a configuration
-func (d *Day10) minPressesToToggle(desired uint, buttons []uint) (presses int, bestButtons uint) {
+// minPressesToToggleBruteForce tries every combination of buttons. It's the reference for minPressesToToggle.
+func (d *Day10) minPressesToToggleBruteForce(desired uint, buttons []uint) (presses int, bestButtons uint) {
 
 	presses = math.MaxInt
 	bestButtons = 0
@@ -262,52 +328,13 @@ func (d *Day10) minPressesToToggle(desired uint, buttons []uint) (presses int, b
 	return presses, bestButtons
 }
 
-func (l *day10Light) oddJoltageBits() uint {
-	var mask uint
-	for i, j := range l.joltage {
-		if j%2 == 1 {
-			// odd number
-			mask |= 1 << i
-		}
-	}
-	return mask
-}
-
-func (l *day10Light) isZeroJoltage() bool {
-	for _, j := range l.joltage {
-		if j > 0 {
-			return false
-		}
-	}
-	return true
-}
-
-// joltageAfterPressing shows the joltage result after pressing buttons
-// in this case we are going towards 0
-func (l *day10Light) reduceJoltage(buttons uint) {
-	for b := range bits.Len(buttons) {
-		if buttons&(1<<b) != 0 {
-			// button b was pressed
-			for _, light := range l.buttonIndices[b] {
-				l.joltage[light]--
-			}
-		}
-	}
-}
-
-func (l *day10Light) halveJoltage() {
-	for i, j := range l.joltage {
-		l.joltage[i] = j / 2
-	}
-}
-
 func (d *Day10) viewLight(l uint, numLights int) string {
 	var sb strings.Builder
 	for i := range numLights {
 		if l&(1<<i) != 0 {
-			sb.WriteString(renderedLightOn)
+			sb.WriteString(d.renderedLightOn)
 		} else {
-			sb.WriteString(renderedLightOff)
+			sb.WriteString(d.renderedLightOff)
 		}
 	}
 	return sb.String()
@@ -316,9 +343,9 @@ func (d *Day10) viewButton(b uint) string {
 	var sb strings.Builder
 	for i := range 10 {
 		if b&(1<<i) != 0 {
-			sb.WriteString(renderedButtonOn)
+			sb.WriteString(d.renderedButtonOn)
 		} else {
-			sb.WriteString(renderedButtonOff)
+			sb.WriteString(d.renderedButtonOff)
 		}
 	}
 	return sb.String()
@@ -339,20 +366,41 @@ func (d *Day10) viewButtons(buttons uint, buttonIndices [][]int) string {
 	return sb.String()
 }
 
+// viewPresses shows how many times part2 presses each button, skipping the ones it doesn't
+func (d *Day10) viewPresses(l *day10Light) string {
+	var sb strings.Builder
+	for i, n := range l.presses {
+		if n == 0 {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("%s%s%s ", d.Theme.Data2.Render(strconv.Itoa(n)), d.Theme.Glyph("×", "x"), l.buttonStrs[i]))
+	}
+	return sb.String()
+}
+
 func (d *Day10) view() string {
 	if d.Quiet {
 		return ""
 	}
-	return ""
+	var sb strings.Builder
+	for i := range d.input {
+		l := &d.input[i]
+		sb.WriteString(fmt.Sprintf("%s %s\n", d.viewLight(l.light, len(l.joltage)), d.viewPresses(l)))
+	}
+	return sb.String()
 }
 
 func (d *Day10) viewSolution() string {
 	return fmt.Sprintf("solution1: %s solution2: %s",
-		solutionStyle.Render(strconv.Itoa(d.solution1)),
-		solutionStyle.Render(strconv.Itoa(d.solution2)),
+		d.Theme.Solution.Render(strconv.Itoa(d.solution1)),
+		d.Theme.Solution.Render(strconv.Itoa(d.solution2)),
 	)
 }
 
+func (d *Day10) answer() Answer {
+	return Answer{Part1: int64(d.solution1), Part2: int64(d.solution2)}
+}
+
 type vecPattern struct {
 	v    []int
 	cost int
@@ -381,10 +429,8 @@ func patterns(coeffs [][]int) []vecPattern {
 	}
 	numVars := len(coeffs[0])
 
-	// key -> minCost
-	minCost := make(map[string]int, 1<<min(numButtons, 20))
-	// key -> representative vector (so we can return []vecPattern without re-decoding)
-	vecByKey := make(map[string][]int, 1<<min(numButtons, 20))
+	// cheapest pattern for each vector
+	best := make(map[day10Key]vecPattern, 1<<min(numButtons, 20))
 
 	for mask := 0; mask < (1 << numButtons); mask++ {
 		p := make([]int, numVars)
@@ -400,28 +446,27 @@ func patterns(coeffs [][]int) []vecPattern {
 			}
 		}
 
-		key := fmt.Sprintf("%v", p)
-		if prev, ok := minCost[key]; !ok || cost < prev {
-			minCost[key] = cost
-			// keep the corresponding vector for this key
-			// (safe because p is newly allocated each loop)
-			vecByKey[key] = p
+		key, _ := memo.ArrayKey[day10Key](p)
+		if prev, ok := best[key]; !ok || cost < prev.cost {
+			// safe because p is newly allocated each loop
+			best[key] = vecPattern{v: p, cost: cost}
 		}
 	}
 
-	out := make([]vecPattern, 0, len(minCost))
-	for key, cost := range minCost {
-		out = append(out, vecPattern{v: vecByKey[key], cost: cost})
+	out := make([]vecPattern, 0, len(best))
+	for _, pat := range best {
+		out = append(out, pat)
 	}
 	return out
 }
 
+// solveSingle is the reference for part2, it only finds the total presses.
 // from reddit, translated from python: https://www.reddit.com/r/adventofcode/comments/1pk87hl/2025_day_10_part_2_bifurcate_your_way_to_victory/
-func solveSingle(coeffs [][]int, goal []int) int {
+func solveSingle(coeffs [][]int, goal []int) (int, memo.Stats) {
 	pats := patterns(coeffs)
 
 	const INF = 1_000_000
-	memo := make(map[string]int, 1<<16)
+	cache := memo.New[day10Key, int](memo.WithCapacity(1 << 16))
 
 	var rec func(g []int) int
 	rec = func(g []int) int {
@@ -436,40 +481,36 @@ func solveSingle(coeffs [][]int, goal []int) int {
 			return 0
 		}
 
-		k := fmt.Sprintf("%v", g)
-		if v, ok := memo[k]; ok {
-			return v
-		}
-
-		best := INF
-		for _, pat := range pats {
-			ok := true
-			for i := range g {
-				pi := pat.v[i]
-				gi := g[i]
-				if pi > gi || (pi&1) != (gi&1) {
-					ok = false
-					break
+		k, _ := memo.ArrayKey[day10Key](g)
+		return cache.Do(k, func() int {
+			best := INF
+			for _, pat := range pats {
+				ok := true
+				for i := range g {
+					pi := pat.v[i]
+					gi := g[i]
+					if pi > gi || (pi&1) != (gi&1) {
+						ok = false
+						break
+					}
+				}
+				if !ok {
+					continue
 				}
-			}
-			if !ok {
-				continue
-			}
 
-			newGoal := make([]int, len(g))
-			for i := range g {
-				newGoal[i] = (g[i] - pat.v[i]) / 2
-			}
+				newGoal := make([]int, len(g))
+				for i := range g {
+					newGoal[i] = (g[i] - pat.v[i]) / 2
+				}
 
-			cand := pat.cost + 2*rec(newGoal)
-			if cand < best {
-				best = cand
+				cand := pat.cost + 2*rec(newGoal)
+				if cand < best {
+					best = cand
+				}
 			}
-		}
-
-		memo[k] = best
-		return best
+			return best
+		})
 	}
 
-	return rec(goal)
+	return rec(goal), cache.Stats()
 }
@@ -0,0 +1,160 @@
+package advent
+
+import (
+	"errors"
+	"math"
+	"math/rand/v2"
+	"strings"
+	"testing"
+
+	"github.com/sirgwain/advent-of-code-2025/advent/linalg"
+)
+
+// the example from the puzzle
+var day10Example = []string{
+	"[.##.] (3) (1,3) (2) (2,3) (0,2) (0,1) {3,5,4,7}",
+	"[...#.] (0,2,3,4) (2,3) (0,4) (0,1,2) (1,2,3,4) {7,5,12,7,2}",
+	"[.###.#] (0,1,2,3,4) (0,3,4) (0,1,2,4,5) (1,2) {10,11,11,5,10,5}",
+}
+
+func TestDay10_Run(t *testing.T) {
+	d := Day10{}
+	if err := d.Init(strings.NewReader(strings.Join(day10Example, "\n")), NewRun(WithQuiet(true))); err != nil {
+		t.Fatalf("Day10.Init() error = %v", err)
+	}
+	updates := make(chan DayUpdate, 1)
+	if err := d.Run(updates); err != nil {
+		t.Fatalf("Day10.Run() error = %v", err)
+	}
+	if got, want := (<-updates).Answer, (Answer{Part1: 7, Part2: 33}); got != want {
+		t.Errorf("Day10.Run() = %v, want %v", got, want)
+	}
+}
+
+func TestDay10_RunQuiet(t *testing.T) {
+	// part1 used to only be added up when the presses were printed, so quiet runs answered 0
+	for _, quiet := range []bool{true, false} {
+		got, err := Solve(10, strings.NewReader(strings.Join(day10Example, "\n")), WithQuiet(quiet))
+		if want := (Answer{Part1: 7, Part2: 33}); err != nil || got != want {
+			t.Errorf("Solve(10) quiet %v = %v, %v, want %v", quiet, got, err, want)
+		}
+	}
+}
+
+// randomButtons makes n buttons that each toggle some of the lights
+func randomButtons(r *rand.Rand, n, lights int) []uint {
+	buttons := make([]uint, n)
+	for i := range buttons {
+		buttons[i] = uint(r.IntN(1<<lights-1) + 1)
+	}
+	return buttons
+}
+
+func TestDay10_RunNoSolution(t *testing.T) {
+	// pressing (0) once reaches the joltage, but no presses of it turn on light 1
+	d := Day10{}
+	if err := d.Init(strings.NewReader("[.#] (0) {1,0}\n"), NewRun(WithQuiet(true))); err != nil {
+		t.Fatalf("Day10.Init() error = %v", err)
+	}
+	updates := make(chan DayUpdate, 1)
+	err := d.Run(updates)
+	if want := "line 1 lights .#: no solution"; !errors.Is(err, linalg.ErrNoSolution) || err.Error() != want {
+		t.Errorf("Day10.Run() error = %v, want %s", err, want)
+	}
+}
+
+func TestDay10_minPressesToToggle(t *testing.T) {
+	d := Day10{}
+	r := rand.New(rand.NewPCG(10, 10))
+	for range 500 {
+		lights := 2 + r.IntN(8)
+		buttons := randomButtons(r, 1+r.IntN(12), lights)
+		// the brute force never presses zero buttons, so skip the all off configuration
+		desired := uint(r.IntN(1<<lights-1) + 1)
+
+		wantPresses, wantButtons := d.minPressesToToggleBruteForce(desired, buttons)
+		gotPresses, gotButtons, err := d.minPressesToToggle(desired, buttons)
+		if wantPresses == math.MaxInt {
+			// the buttons can't make this configuration
+			if !errors.Is(err, linalg.ErrNoSolution) {
+				t.Fatalf("minPressesToToggle(%b, %b) error = %v, want %v", desired, buttons, err, linalg.ErrNoSolution)
+			}
+			continue
+		}
+		if err != nil || gotPresses != wantPresses || gotButtons != wantButtons {
+			t.Fatalf("minPressesToToggle(%b, %b) = %d, %b, %v, want %d, %b",
+				desired, buttons, gotPresses, gotButtons, err, wantPresses, wantButtons)
+		}
+	}
+}
+
+func BenchmarkDay10Part1(b *testing.B) {
+	d := Day10{}
+	r := rand.New(rand.NewPCG(10, 10))
+	buttons := randomButtons(r, 20, 10)
+	desired := uint(0b1011001101)
+
+	b.Run("bruteforce", func(b *testing.B) {
+		for b.Loop() {
+			d.minPressesToToggleBruteForce(desired, buttons)
+		}
+	})
+	b.Run("gf2", func(b *testing.B) {
+		for b.Loop() {
+			d.minPressesToToggle(desired, buttons)
+		}
+	})
+}
+
+// randomMachine makes a machine's buttons and a joltage some presses of them can reach
+func randomMachine(r *rand.Rand, buttons, counters int) (coeffs [][]int, joltage []int) {
+	coeffs = make([][]int, buttons)
+	joltage = make([]int, counters)
+	for i := range coeffs {
+		coeffs[i] = make([]int, counters)
+		for j := range coeffs[i] {
+			coeffs[i][j] = r.IntN(2)
+		}
+		presses := r.IntN(20)
+		for j, c := range coeffs[i] {
+			joltage[j] += c * presses
+		}
+	}
+	return coeffs, joltage
+}
+
+func TestDay10_part2(t *testing.T) {
+	r := rand.New(rand.NewPCG(44, 44))
+	for range 100 {
+		coeffs, joltage := randomMachine(r, 3+r.IntN(6), 3+r.IntN(5))
+		want, _ := solveSingle(coeffs, joltage)
+
+		presses, err := linalg.MinSumSolution(coeffs, joltage)
+		if err != nil {
+			t.Fatalf("MinSumSolution(%v, %v) error = %v, want %d presses", coeffs, joltage, err, want)
+		}
+		got := 0
+		for _, n := range presses {
+			got += n
+		}
+		if got != want {
+			t.Fatalf("MinSumSolution(%v, %v) = %v, %d presses, want %d", coeffs, joltage, presses, got, want)
+		}
+	}
+}
+
+func BenchmarkDay10Part2(b *testing.B) {
+	r := rand.New(rand.NewPCG(44, 44))
+	coeffs, joltage := randomMachine(r, 10, 8)
+
+	b.Run("bifurcate", func(b *testing.B) {
+		for b.Loop() {
+			solveSingle(coeffs, joltage)
+		}
+	})
+	b.Run("ilp", func(b *testing.B) {
+		for b.Loop() {
+			linalg.MinSumSolution(coeffs, joltage)
+		}
+	})
+}
@@ -2,52 +2,52 @@ package advent
 
 import (
 	"fmt"
-	"os"
+	"io"
 	"slices"
 	"strconv"
 	"strings"
 
-	"github.com/charmbracelet/lipgloss"
-	"github.com/sirgwain/advent-of-code-2025/advent/color"
-)
-
-var (
-	renderedDac = lipgloss.NewStyle().Foreground(color.BrightGreen82).Render("dac")
-	renderedFft = lipgloss.NewStyle().Foreground(color.BrightLilac177).Render("fft")
+	"github.com/sirgwain/advent-of-code-2025/advent/graph"
+	"github.com/sirgwain/advent-of-code-2025/advent/parse"
 )
 
 type Day11 struct {
 	*Options
-	input         []*day11Node
-	linksCache    map[string]int
-	svrToDacLinks int
-	svrToFftLinks int
-	dacToFftLinks int
-	fftToDacLinks int
-	dacToOutLinks int
-	fftToOutLinks int
-	solution1     int
-	solution2     int
+	links     graph.AdjacencyMap[string]
+	from      string
+	to        string
+	via       []string
+	allLinks  int
+	solution1 int
+	solution2 int
 }
 
-type day11Node struct {
-	key   string
-	links []*day11Node
+// day11Record is a device and the devices its outputs go to, like you: bbb ccc
+type day11Record struct {
+	Key  string
+	Outs []string
 }
 
-func (n *day11Node) String() string {
-	return n.key
-}
+var day11Format = parse.MustCompile[day11Record]("{Key}: {Outs: }")
 
 func (d *Day11) Day() int {
 	return 11
 }
 
-// Init loads in the input from the file and initializes the Day
-func (d *Day11) Init(filename string, options *Options) (err error) {
+// Init loads in the input and initializes the Day
+func (d *Day11) Init(r io.Reader, options *Options) (err error) {
 	d.Options = options
+	defer func() { err = asParseError(d.Day(), err) }()
 
-	content, err := os.ReadFile(filename)
+	// part2 counts the paths from svr to out through dac and fft, unless the params ask for different ones
+	d.from = d.Param("from", "svr")
+	d.to = d.Param("to", "out")
+	d.via = nil
+	if via := d.Param("via", "dac,fft"); via != "" {
+		d.via = strings.Split(via, ",")
+	}
+
+	in, err := readInput(r, d.InputName)
 	if err != nil {
 		return err
 	}
@@ -57,43 +57,29 @@ func (d *Day11) Init(filename string, options *Options) (err error) {
 	// bbb: ddd eee
 	// ccc: ddd eee fff
 
-	lines := strings.Split(string(content), "\n")
-
-	nodesByKey := make(map[string]*day11Node, len(lines))
-	d.input = make([]*day11Node, 0, len(lines))
-	for _, line := range lines {
-		split := strings.Split(line, ": ")
-		key := strings.TrimSpace(split[0])
-		var n *day11Node
-		if existing, ok := nodesByKey[key]; ok {
-			n = existing
-		} else {
-			n = &day11Node{key: key}
-			nodesByKey[key] = n
+	d.links = make(graph.AdjacencyMap[string], len(in.Lines))
+	for _, section := range in.Sections() {
+		records, err := day11Format.Records(section)
+		if err != nil {
+			return err
+		}
+		for _, record := range records {
+			d.links[record.Key] = record.Outs
 		}
+	}
 
-		outKeys := strings.Split(strings.TrimSpace(split[1]), " ")
-		n.links = make([]*day11Node, 0, len(outKeys))
-		for _, key := range outKeys {
-			var out *day11Node
-			if existing, ok := nodesByKey[key]; ok {
-				out = existing
-			} else {
-				out = &day11Node{key: key}
-				nodesByKey[key] = out
+	// nodes that only have links in, like out, still belong in the graph
+	for _, outs := range d.links {
+		for _, out := range outs {
+			if _, ok := d.links[out]; !ok {
+				d.links[out] = nil
 			}
-			n.links = append(n.links, out)
 		}
-
-		d.input = append(d.input, n)
 	}
 	return nil
 }
 
 func (d *Day11) Run(updates chan<- DayUpdate) error {
-	// we cache various root -> sub, sub -> out style link counts
-	d.linksCache = make(map[string]int, len(d.input)*6)
-
 	if err := d.part1(); err != nil {
 		return err
 	}
@@ -105,139 +91,72 @@ func (d *Day11) Run(updates chan<- DayUpdate) error {
 	updates <- DayUpdate{
 		View:     d.view(),
 		Solution: d.viewSolution(),
+		Answer:   d.answer(),
 		Done:     true,
 	}
 	return nil
 }
 
-func (d *Day11) getNode(key string) *day11Node {
-	idx := slices.IndexFunc(d.input, func(n *day11Node) bool { return n.key == key })
-	if idx == -1 {
-		return nil
-	}
-	return d.input[idx]
-
-}
-
-func (d *Day11) traverse(in *day11Node, out string, path []string) int {
-	cacheKey := in.key + "_" + out
-	if c, ok := d.linksCache[cacheKey]; ok {
-		return c
-	}
-
-	if in.key == out {
-		// fmt.Printf("%s\n", d.viewPath(path))
-		return 1
+// countPaths counts the paths from one node to another through every waypoint, making sure the nodes exist
+func (d *Day11) countPaths(from, to string, via ...string) (int, error) {
+	for _, key := range append([]string{from, to}, via...) {
+		if _, ok := d.links[key]; !ok {
+			return 0, fmt.Errorf("no %s found in data", key)
+		}
 	}
-
-	links := 0
-	for _, link := range in.links {
-		links += d.traverse(link, out, append(path, link.key))
+	count, err := graph.CountPaths(d.links, from, to, via...)
+	if err != nil {
+		return 0, fmt.Errorf("counting paths from %s to %s: %w", from, to, err)
 	}
-	d.linksCache[cacheKey] = links
-	return links
+	return count, nil
 }
 
-func (d *Day11) part1() error {
-	you := d.getNode("you")
-	if you == nil {
-		return fmt.Errorf("no you found in data")
-	}
-
-	d.solution1 = d.traverse(you, "out", []string{you.key})
-
-	return nil
+func (d *Day11) part1() (err error) {
+	d.solution1, err = d.countPaths("you", "out")
+	return err
 }
 
-func (d *Day11) part2(updates chan<- DayUpdate) error {
-	svr := d.getNode("svr")
-	if svr == nil {
-		return fmt.Errorf("no svr found in data")
-	}
-
-	dac := d.getNode("dac")
-	if dac == nil {
-		return fmt.Errorf("no dac found in data")
+func (d *Day11) part2(updates chan<- DayUpdate) (err error) {
+	if d.allLinks, err = d.countPaths(d.from, d.to); err != nil {
+		return err
 	}
-
-	fft := d.getNode("fft")
-	if fft == nil {
-		return fmt.Errorf("no fft found in data")
+	if d.solution2, err = d.countPaths(d.from, d.to, d.via...); err != nil {
+		return err
 	}
 
-	fmt.Printf("\nfinding svr -> out\n")
-	d.traverse(svr, "out", []string{svr.key})
-
-	fmt.Printf("\nfinding svr -> dac\n")
-	d.svrToDacLinks = d.traverse(svr, "dac", []string{svr.key})
-	fmt.Printf("svr -> dac: %s\n", data2Style.Render(strconv.Itoa(d.svrToDacLinks)))
-
-	fmt.Printf("\nfinding dac -> fft\n")
-	d.dacToFftLinks = d.traverse(dac, "fft", []string{dac.key})
-	fmt.Printf("dac -> fft: %s\n", data2Style.Render(strconv.Itoa(d.dacToFftLinks)))
-
-	fmt.Printf("\nfinding fft -> out\n")
-	d.fftToOutLinks = d.traverse(fft, "out", []string{fft.key})
-	fmt.Printf("fft -> out: %s\n", data2Style.Render(strconv.Itoa(d.fftToOutLinks)))
-
-	fmt.Printf("\nfinding svr -> fft\n")
-	d.svrToFftLinks = d.traverse(svr, "fft", []string{svr.key})
-	fmt.Printf("svr -> fft: %s\n", data2Style.Render(strconv.Itoa(d.svrToFftLinks)))
-
-	fmt.Printf("\nfinding fft -> dac\n")
-	d.fftToDacLinks = d.traverse(fft, "dac", []string{fft.key})
-	fmt.Printf("fft -> dac: %s\n", data2Style.Render(strconv.Itoa(d.fftToDacLinks)))
-
-	fmt.Printf("\nfinding dac -> out\n")
-	d.dacToOutLinks = d.traverse(dac, "out", []string{dac.key})
-	fmt.Printf("dac -> out: %s\n", data2Style.Render(strconv.Itoa(d.dacToOutLinks)))
-
-	d.solution2 += d.svrToDacLinks * d.dacToFftLinks * d.fftToOutLinks
-	d.solution2 += d.svrToFftLinks * d.fftToDacLinks * d.dacToOutLinks
-
 	updates <- DayUpdate{
 		View:     d.view(),
 		Solution: d.viewSolution(),
+		Answer:   d.answer(),
 		Done:     false,
 	}
 
 	return nil
 }
 
-func (d *Day11) viewPath(path []string) string {
-	var sb strings.Builder
-	for i, p := range path {
-		if i > 0 {
-			sb.WriteRune(' ')
-		}
-
-		switch p {
-		case "fft":
-			sb.WriteString(renderedFft)
-		case "dac":
-			sb.WriteString(renderedDac)
-		default:
-			sb.WriteString(p)
-		}
+// viewVia lists the waypoints
+func (d *Day11) viewVia() string {
+	via := make([]string, len(d.via))
+	for i, key := range d.via {
+		via[i] = d.Theme.Correct.Render(key)
 	}
-	return sb.String()
+	return strings.Join(via, ", ")
 }
 
-func (d *Day11) viewNode(n *day11Node) string {
+func (d *Day11) viewNode(key string) string {
 	var sb strings.Builder
-	for i, out := range n.links {
+	for i, out := range d.links[key] {
 		if i > 0 {
 			sb.WriteRune(' ')
 		}
-		switch out.key {
-		case "fft", "dac":
-			sb.WriteString(correctResultStyle.Render(out.key))
-		default:
-			sb.WriteString(data2Style.Render(out.key))
+		if slices.Contains(d.via, out) {
+			sb.WriteString(d.Theme.Correct.Render(out))
+		} else {
+			sb.WriteString(d.Theme.Data2.Render(out))
 		}
 	}
 	return fmt.Sprintf("%s: [%s]",
-		data1Style.Render(n.key),
+		d.Theme.Data1.Render(key),
 		sb.String(),
 	)
 }
@@ -248,20 +167,19 @@ func (d *Day11) view() string {
 	}
 	var sb strings.Builder
 	sb.WriteString("\n")
-	sb.WriteString(fmt.Sprintf("svr -> dac: %s\n", data2Style.Render(strconv.Itoa(d.svrToDacLinks))))
-	sb.WriteString(fmt.Sprintf("dac -> fft: %s\n", data2Style.Render(strconv.Itoa(d.dacToFftLinks))))
-	sb.WriteString(fmt.Sprintf("fft -> out: %s\n", data2Style.Render(strconv.Itoa(d.fftToOutLinks))))
-	sb.WriteString("\n")
-	sb.WriteString(fmt.Sprintf("svr -> fft: %s\n", data2Style.Render(strconv.Itoa(d.svrToFftLinks))))
-	sb.WriteString(fmt.Sprintf("fft -> dac: %s\n", data2Style.Render(strconv.Itoa(d.fftToDacLinks))))
-	sb.WriteString(fmt.Sprintf("dac -> out: %s\n", data2Style.Render(strconv.Itoa(d.dacToOutLinks))))
+	sb.WriteString(fmt.Sprintf("%s -> %s: %s\n", d.from, d.to, d.Theme.Data2.Render(strconv.Itoa(d.allLinks))))
+	sb.WriteString(fmt.Sprintf("%s -> %s via %s: %s\n", d.from, d.to, d.viewVia(), d.Theme.Data2.Render(strconv.Itoa(d.solution2))))
 
 	return sb.String()
 }
 
 func (d *Day11) viewSolution() string {
 	return fmt.Sprintf("solution1: %s solution2: %s",
-		solutionStyle.Render(strconv.Itoa(d.solution1)),
-		solutionStyle.Render(strconv.Itoa(d.solution2)),
+		d.Theme.Solution.Render(strconv.Itoa(d.solution1)),
+		d.Theme.Solution.Render(strconv.Itoa(d.solution2)),
 	)
 }
+
+func (d *Day11) answer() Answer {
+	return Answer{Part1: int64(d.solution1), Part2: int64(d.solution2)}
+}
@@ -0,0 +1,70 @@
+package advent
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// the examples from both parts of the puzzle, with the part 1 nodes renamed so they can share a graph
+var day11Example = []string{
+	"you: b1 c1",
+	"b1: d1 e1",
+	"c1: d1 e1 f1",
+	"d1: g1",
+	"e1: out",
+	"f1: out",
+	"g1: out",
+	"h1: c1 f1 i1",
+	"i1: out",
+	"svr: aaa bbb",
+	"aaa: fft",
+	"fft: ccc",
+	"bbb: tty",
+	"tty: ccc",
+	"ccc: ddd eee",
+	"ddd: hub",
+	"hub: fff",
+	"eee: dac",
+	"dac: fff",
+	"fff: ggg hhh",
+	"ggg: out",
+	"hhh: out",
+}
+
+func TestDay11_Run(t *testing.T) {
+	tests := []struct {
+		name    string
+		lines   []string
+		params  map[string]string
+		want    Answer
+		wantErr bool
+	}{
+		{"example", day11Example, nil, Answer{Part1: 5, Part2: 2}, false},
+		{"via fft", day11Example, map[string]string{"via": "fft"}, Answer{Part1: 5, Part2: 4}, false},
+		{"no waypoints", day11Example, map[string]string{"via": ""}, Answer{Part1: 5, Part2: 8}, false},
+		{"other endpoints", day11Example, map[string]string{"from": "h1", "to": "out", "via": "f1"}, Answer{Part1: 5, Part2: 2}, false},
+		{"missing node", day11Example, map[string]string{"via": "zzz"}, Answer{}, true},
+		{"cycle", append(slices.Clone(day11Example), "out: you"), nil, Answer{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := Day11{}
+			if err := d.Init(strings.NewReader(strings.Join(tt.lines, "\n")), NewRun(WithQuiet(true), WithParams(tt.params))); err != nil {
+				t.Fatalf("Day11.Init() error = %v", err)
+			}
+			updates := make(chan DayUpdate, 2)
+			err := d.Run(updates)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Day11.Run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			<-updates // part 2's progress update
+			if got := (<-updates).Answer; got != tt.want {
+				t.Errorf("Day11.Run() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
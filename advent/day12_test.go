@@ -0,0 +1,63 @@
+package advent
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// the example from the puzzle, the last board has room for the pieces but they don't fit
+var day12Example = []string{
+	"0:", "###", "##.", "##.", "",
+	"1:", "###", "##.", ".##", "",
+	"2:", ".##", "###", "##.", "",
+	"3:", "##.", "###", "##.", "",
+	"4:", "###", "#..", "###", "",
+	"5:", "###", ".#.", "###", "",
+	"4x4: 0 0 0 0 2 0",
+	"12x5: 1 0 1 0 2 2",
+	"12x5: 1 0 1 0 3 2",
+}
+
+func TestDay12_Run(t *testing.T) {
+	d := Day12{}
+	if err := d.Init(strings.NewReader(strings.Join(day12Example, "\n")), NewRun(WithQuiet(true))); err != nil {
+		t.Fatalf("Day12.Init() error = %v", err)
+	}
+	updates := make(chan DayUpdate, 1)
+	if err := d.Run(updates); err != nil {
+		t.Fatalf("Day12.Run() error = %v", err)
+	}
+	if got, want := (<-updates).Answer, (Answer{Part1: 2}); got != want {
+		t.Errorf("Day12.Run() = %v, want %v", got, want)
+	}
+}
+
+func TestDay12_InitErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		replace [2]string // a line of the example and what to replace it with
+		wantErr string
+	}{
+		{"bad cell", [2]string{".#.", ".o."}, "day12.txt:28:2: shapes are drawn with # and ., not 'o'"},
+		{"shape out of order", [2]string{"3:", "7:"}, "day12.txt:16:1: expected shape 3, shapes must be in order"},
+		{"negative size", [2]string{"12x5: 1 0 1 0 2 2", "-3x5: 1 0 1 0 2 2"}, "day12.txt:32:1: board must be at least 1x1, not -3x5"},
+		{"empty board", [2]string{"12x5: 1 0 1 0 2 2", "12x0: 1 0 1 0 2 2"}, "day12.txt:32:1: board must be at least 1x1, not 12x0"},
+		{"bad requirement", [2]string{"12x5: 1 0 1 0 2 2", "12x5: 1 0 1 O 2 2"}, `day12.txt:32:13: invalid Requirements: not a valid int: "O"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := slices.Clone(day12Example)
+			lines[slices.Index(lines, tt.replace[0])] = tt.replace[1]
+
+			d := Day12{}
+			err := d.Init(strings.NewReader(strings.Join(lines, "\n")), NewRun(WithQuiet(true), WithInputName("day12.txt")))
+			want := "day 12: " + tt.wantErr
+			var perr *ParseError
+			if !errors.As(err, &perr) || err.Error() != want {
+				t.Errorf("Day12.Init() error = %v, want %s", err, want)
+			}
+		})
+	}
+}
@@ -2,10 +2,11 @@ package advent
 
 import (
 	"fmt"
+	"io"
 	"math"
-	"os"
 	"strconv"
-	"strings"
+
+	"github.com/sirgwain/advent-of-code-2025/advent/parse"
 )
 
 type Day2 struct {
@@ -16,37 +17,45 @@ type Day2 struct {
 	solution2 int64
 }
 
+// day2Range is a range of ids, like 11-22
+type day2Range Interval[int]
+
+func (r *day2Range) UnmarshalText(text []byte) error {
+	v, err := day2RangeFormat.Parse(string(text))
+	*r = day2Range(v)
+	return err
+}
+
+var (
+	day2RangeFormat = parse.MustCompile[Interval[int]]("{Low}-{High}")
+	day2Format      = parse.MustCompile[[]day2Range]("{:,}")
+)
+
 func (d *Day2) Day() int {
 	return 2
 }
 
-// Init loads in the input from the file and initializes the Day
-func (d *Day2) Init(filename string, options *Options) (err error) {
+// Init loads in the input and initializes the Day
+func (d *Day2) Init(r io.Reader, options *Options) (err error) {
 	d.Options = options
+	defer func() { err = asParseError(d.Day(), err) }()
 
-	content, err := os.ReadFile(filename)
-
+	in, err := readInput(r, d.InputName)
 	if err != nil {
 		return err
 	}
 
-	ranges := strings.Split(string(content), ",")
-	input := make([][2]int, len(ranges))
-
-	for i, idRange := range ranges {
-		ids := strings.Split(idRange, "-")
-		input[i][0], err = strconv.Atoi(ids[0])
+	// 11-22,95-115,998-1012, usually all on one line
+	for _, line := range in.Lines {
+		ranges, err := day2Format.ParseLine(line)
 		if err != nil {
-			return fmt.Errorf("not a number %s %v", ids[0], err)
+			return err
 		}
-		input[i][1], err = strconv.Atoi(ids[1])
-		if err != nil {
-			return fmt.Errorf("not a number %s %v", ids[1], err)
+		for _, idRange := range ranges {
+			d.input = append(d.input, [2]int{idRange.Low, idRange.High})
 		}
 	}
 
-	d.input = input
-
 	return nil
 }
 
@@ -62,6 +71,7 @@ func (d *Day2) Run(updates chan<- DayUpdate) error {
 			updates <- DayUpdate{
 				View:     d.view(),
 				Solution: d.viewSolution(),
+				Answer:   d.answer(),
 				Done:     d.done(),
 			}
 		}
@@ -70,6 +80,7 @@ func (d *Day2) Run(updates chan<- DayUpdate) error {
 	updates <- DayUpdate{
 		View:     d.view(),
 		Solution: d.viewSolution(),
+		Answer:   d.answer(),
 		Done:     d.done(),
 	}
 
@@ -112,19 +123,23 @@ func (d *Day2) view() string {
 	step := min(d.step, len(d.input)-1)
 	return fmt.Sprintf("S%d ID Range %s - %s",
 		step,
-		correctResultStyle.Render(strconv.Itoa(d.input[step][0])),
-		correctResultStyle.Render(strconv.Itoa(d.input[step][1])),
+		d.Theme.Correct.Render(strconv.Itoa(d.input[step][0])),
+		d.Theme.Correct.Render(strconv.Itoa(d.input[step][1])),
 	)
 
 }
 
 func (d *Day2) viewSolution() string {
 	return fmt.Sprintf("solution1: %s, solution2: %s",
-		solutionStyle.Render(strconv.FormatInt(d.solution1, 10)),
-		solutionStyle.Render(strconv.FormatInt(d.solution2, 10)),
+		d.Theme.Solution.Render(strconv.FormatInt(d.solution1, 10)),
+		d.Theme.Solution.Render(strconv.FormatInt(d.solution2, 10)),
 	)
 }
 
+func (d *Day2) answer() Answer {
+	return Answer{Part1: int64(d.solution1), Part2: int64(d.solution2)}
+}
+
 // isTwoRepeatingNumbers returns true if this number contains two numbers repeating
 func isTwoRepeatingNumbers(num int) bool {
 	digits := len(strconv.Itoa(num))
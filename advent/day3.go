@@ -2,7 +2,7 @@ package advent
 
 import (
 	"fmt"
-	"os"
+	"io"
 	"strconv"
 	"strings"
 	"sync"
@@ -17,6 +17,9 @@ type Day3 struct {
 	solution2 int
 }
 
+// day3Batteries is how many batteries part2 turns on in each bank
+const day3Batteries = 12
+
 type day3Workload struct {
 	num       int    // the job number
 	str       string // the string to evaluate
@@ -28,16 +31,27 @@ func (d *Day3) Day() int {
 	return 3
 }
 
-// Init loads in the input from the file and initializes the Day
-func (d *Day3) Init(filename string, options *Options) (err error) {
+// Init loads in the input and initializes the Day
+func (d *Day3) Init(r io.Reader, options *Options) (err error) {
 	d.Options = options
-	content, err := os.ReadFile(filename)
-
+	defer func() { err = asParseError(d.Day(), err) }()
+	in, err := readInput(r, d.InputName)
 	if err != nil {
 		return err
 	}
 
-	d.input = strings.Split(string(content), "\n")
+	// every bank is a line of digits, with enough batteries to turn on
+	for _, line := range in.Lines {
+		for col, r := range []rune(line.Text) {
+			if r < '0' || r > '9' {
+				return line.Errorf(col+1, "batteries must be digits, not %q", r)
+			}
+		}
+		if len(line.Text) < day3Batteries {
+			return line.Errorf(0, "a bank needs at least %d batteries, it has %d", day3Batteries, len(line.Text))
+		}
+	}
+	d.input = in.Text()
 	d.highest2 = make([]int, len(d.input))
 	d.highest12 = make([]int, len(d.input))
 	return nil
@@ -58,7 +72,7 @@ func (d *Day3) Run(updates chan<- DayUpdate) error {
 				return err
 			}
 
-			highest12, err := highestNDigits(job.str, 12)
+			highest12, err := highestNDigits(job.str, day3Batteries)
 			if err != nil {
 				return err
 			}
@@ -100,6 +114,7 @@ func (d *Day3) Run(updates chan<- DayUpdate) error {
 			updates <- DayUpdate{
 				View:     d.view(),
 				Solution: d.viewSolution(),
+				Answer:   d.answer(),
 				Done:     false,
 			}
 		}
@@ -108,6 +123,7 @@ func (d *Day3) Run(updates chan<- DayUpdate) error {
 	updates <- DayUpdate{
 		View:     d.view(),
 		Solution: d.viewSolution(),
+		Answer:   d.answer(),
 		Done:     true,
 	}
 
@@ -127,9 +143,9 @@ func (d *Day3) view() string {
 		}
 		sb.WriteString(fmt.Sprintf("S%d %s highest 2: %s, highest 12: %s\n",
 			i,
-			data1Style.Render(input),
-			correctResultStyle.Render(strconv.Itoa(d.highest2[i])),
-			correctResultStyle.Render(strconv.Itoa(d.highest12[i])),
+			d.Theme.Data1.Render(input),
+			d.Theme.Correct.Render(strconv.Itoa(d.highest2[i])),
+			d.Theme.Correct.Render(strconv.Itoa(d.highest12[i])),
 		))
 	}
 	return sb.String()
@@ -137,11 +153,15 @@ func (d *Day3) view() string {
 
 func (d *Day3) viewSolution() string {
 	return fmt.Sprintf("solution1: %s solution2: %s",
-		solutionStyle.Render(strconv.Itoa(d.solution1)),
-		solutionStyle.Render(strconv.Itoa(d.solution2)),
+		d.Theme.Solution.Render(strconv.Itoa(d.solution1)),
+		d.Theme.Solution.Render(strconv.Itoa(d.solution2)),
 	)
 }
 
+func (d *Day3) answer() Answer {
+	return Answer{Part1: int64(d.solution1), Part2: int64(d.solution2)}
+}
+
 func highestTwoDigits(str string) (int, error) {
 	var high1, high2 int
 
@@ -2,37 +2,37 @@ package advent
 
 import (
 	"fmt"
+	"io"
 	"strconv"
-	"strings"
 )
 
 type Day4 struct {
 	*Options
-	board        [][]rune
+	board        *Grid[rune]
 	validSquares map[Point]bool
 	solution1    int
 	solution2    int
-}
 
-// prerender some styled characters
-var (
-	renderedPaperTowel = boxStyle.Render("@")
-)
+	// prerender some styled characters
+	renderedPaperTowel string
+}
 
 func (d *Day4) Day() int {
 	return 4
 }
 
-// Init loads in the input from the file and initializes the Day
-func (d *Day4) Init(filename string, options *Options) (err error) {
+// Init loads in the input and initializes the Day
+func (d *Day4) Init(r io.Reader, options *Options) (err error) {
 	d.Options = options
+	defer func() { err = asParseError(d.Day(), err) }()
 
-	input, err := ReadInputAsRunes(filename)
+	// a grid of paper towels, @, and empty floor, .
+	d.board, err = readGrid(r, d.InputName, "@.")
 	if err != nil {
 		return err
 	}
-	d.board = input
 	d.validSquares = make(map[Point]bool)
+	d.renderedPaperTowel = d.Theme.Box.Render("@")
 	return nil
 }
 
@@ -40,9 +40,9 @@ func (d *Day4) Run(updates chan<- DayUpdate) error {
 
 	iteration := 0
 	for {
-		for y := 0; y < len(d.board); y++ {
-			for x := 0; x < len(d.board[y]); x++ {
-				if GetBoardValue(x, y, d.board) != '@' {
+		for y, row := range d.board.Rows() {
+			for x, r := range row {
+				if r != '@' {
 					continue
 				}
 				// check if there are less than 4 papertowels adjacent to us
@@ -61,6 +61,7 @@ func (d *Day4) Run(updates chan<- DayUpdate) error {
 				updates <- DayUpdate{
 					View:     d.view(),
 					Solution: d.viewSolution(),
+					Answer:   d.answer(),
 				}
 			}
 		}
@@ -70,7 +71,7 @@ func (d *Day4) Run(updates chan<- DayUpdate) error {
 		}
 		// remove valid squares
 		for p := range d.validSquares {
-			d.board[p.Y][p.X] = '.'
+			d.board.Set(p, '.')
 		}
 		d.validSquares = map[Point]bool{}
 		iteration++
@@ -79,6 +80,7 @@ func (d *Day4) Run(updates chan<- DayUpdate) error {
 	updates <- DayUpdate{
 		View:     d.view(),
 		Solution: d.viewSolution(),
+		Answer:   d.answer(),
 		Done:     true,
 	}
 
@@ -86,11 +88,10 @@ func (d *Day4) Run(updates chan<- DayUpdate) error {
 }
 
 // countAdjacent check for the existence of a rune adjacent to a position
-func countAdjacent(board [][]rune, pos Point, r rune) int {
+func countAdjacent(board *Grid[rune], pos Point, r rune) int {
 	count := 0
-	for _, d := range AdjacentDirections {
-		square := pos.addDirection(d)
-		if GetBoardValue(square.X, square.Y, board) == r {
+	for _, v := range board.Neighbors8(pos) {
+		if v == r {
 			count++
 		}
 	}
@@ -103,30 +104,27 @@ func (d *Day4) view() string {
 		return ""
 	}
 
-	var sb strings.Builder
-	for y, line := range d.board {
-		for x, r := range line {
-			pos := Point{x, y}
-			if d.validSquares[pos] {
-				sb.WriteString(boxHighlightStyle.Render(string(r)))
-				continue
-			}
-			switch r {
-			case '@':
-				sb.WriteString(renderedPaperTowel)
-			default:
-				sb.WriteRune(r)
-			}
+	return d.board.Render(func(pos Point, r rune) string {
+		if d.validSquares[pos] {
+			return d.Theme.BoxHighlight.Render(string(r))
 		}
-		sb.WriteString("\n")
-	}
-	return sb.String()
+		switch r {
+		case '@':
+			return d.renderedPaperTowel
+		default:
+			return string(r)
+		}
+	})
 }
 
 func (d *Day4) viewSolution() string {
 	return fmt.Sprintf("solution1: %s solution2: %s",
-		solutionStyle.Render(strconv.Itoa(d.solution1)),
-		solutionStyle.Render(strconv.Itoa(d.solution2)),
+		d.Theme.Solution.Render(strconv.Itoa(d.solution1)),
+		d.Theme.Solution.Render(strconv.Itoa(d.solution2)),
 	)
 
 }
+
+func (d *Day4) answer() Answer {
+	return Answer{Part1: int64(d.solution1), Part2: int64(d.solution2)}
+}
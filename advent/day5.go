@@ -1,14 +1,18 @@
 package advent
 
 import (
-	"bufio"
+	"cmp"
 	"fmt"
+	"io"
 	"log/slog"
 	"math"
-	"os"
 	"slices"
 	"strconv"
 	"strings"
+	"sync/atomic"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/sirgwain/advent-of-code-2025/advent/parse"
 )
 
 const (
@@ -16,19 +20,15 @@ const (
 	gridHeight = 200
 )
 
-type int64Range struct {
-	low  int64
-	high int64
-}
-
 type Day5 struct {
 	*Options
-	inputRanges  []int64Range // two ingredient id ranges, i.e. 474206951121632-478696506672479
-	inputRange   int64Range   // min/max of the range
-	inputIDs     []int64      // an id to check, i.e. 223088071752434
-	ranges       []int64Range
-	mergedRanges map[int]bool
+	inputRanges  []Interval[int64] // two ingredient id ranges, i.e. 474206951121632-478696506672479
+	inputRange   Interval[int64]   // min/max of the range
+	inputIDs     []int64           // an id to check, i.e. 223088071752434
+	ranges       *IntervalSet[int64]
+	mergedRanges map[int64]bool // ranges, by low, that have been merged with another range
 	grid         [][]byte
+	showGrid     atomic.Bool // toggled from the visualizer
 	solution1    int
 	solution2    int64
 }
@@ -37,58 +37,39 @@ func (d *Day5) Day() int {
 	return 5
 }
 
-// Init loads in the input from the file and initializes the Day
-func (d *Day5) Init(filename string, options *Options) (err error) {
+var (
+	day5RangeFormat = parse.MustCompile[Interval[int64]]("{Low}-{High}")
+	day5IDFormat    = parse.MustCompile[int64]("{}")
+)
+
+// Init loads in the input and initializes the Day
+func (d *Day5) Init(r io.Reader, options *Options) (err error) {
 	d.Options = options
-	file, err := os.Open(filename)
+	defer func() { err = asParseError(d.Day(), err) }()
+	in, err := readInput(r, d.InputName)
 	if err != nil {
-		return fmt.Errorf("error opening file: %w", err)
+		return err
 	}
-	defer file.Close()
-
-	idMode := false
-	d.inputRange.low = math.MaxInt64
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := scanner.Text()
 
-		// blank line is new input type
-		if strings.TrimSpace(line) == "" {
-			idMode = true
-			continue
-		}
-
-		if idMode {
-			num, err := strconv.ParseInt(line, 10, 64)
-			if err != nil {
-				return fmt.Errorf("error parsing number on line: %s", line)
-			}
-			d.inputIDs = append(d.inputIDs, num)
-			continue
-		}
-
-		// split ids, 92714816788170-94137721164754
-		split := strings.Split(line, "-")
-		if len(split) != 2 {
-			return fmt.Errorf("error splitting range input line")
-		}
-
-		low, err := strconv.ParseInt(split[0], 10, 64)
-		if err != nil {
-			return fmt.Errorf("error parsing number on line: %s", line)
-		}
-
-		high, err := strconv.ParseInt(split[1], 10, 64)
-		if err != nil {
-			return fmt.Errorf("error parsing number on line: %s", line)
-		}
+	// ranges, a blank line, then ids
+	sections := in.Sections()
+	if len(sections) != 2 {
+		return in.Errorf("expected ranges and ids separated by a blank line, found %d sections", len(sections))
+	}
+	if d.inputRanges, err = day5RangeFormat.Records(sections[0]); err != nil {
+		return err
+	}
+	if d.inputIDs, err = day5IDFormat.Records(sections[1]); err != nil {
+		return err
+	}
 
-		d.inputRange.low = min(low, d.inputRange.low)
-		d.inputRange.high = max(high, d.inputRange.high)
-		d.inputRanges = append(d.inputRanges, int64Range{low, high})
+	d.inputRange.Low = math.MaxInt64
+	for _, r := range d.inputRanges {
+		d.inputRange.Low = min(r.Low, d.inputRange.Low)
+		d.inputRange.High = max(r.High, d.inputRange.High)
 	}
 
-	slog.Debug(fmt.Sprintf("input range: %d..%d (dist: %d)", d.inputRange.low, d.inputRange.high, (d.inputRange.high - d.inputRange.low)))
+	slog.Debug(fmt.Sprintf("input range: %d..%d (dist: %d)", d.inputRange.Low, d.inputRange.High, (d.inputRange.High - d.inputRange.Low)))
 
 	// Allocate 400x200 grid (y-major: grid[y][x])
 	d.grid = make([][]byte, gridHeight)
@@ -96,9 +77,6 @@ func (d *Day5) Init(filename string, options *Options) (err error) {
 		d.grid[y] = make([]byte, gridWidth)
 	}
 
-	if err := scanner.Err(); err != nil {
-		return fmt.Errorf("error reading file: %w", err)
-	}
 	return nil
 }
 
@@ -112,6 +90,7 @@ func (d *Day5) Run(updates chan<- DayUpdate) error {
 			updates <- DayUpdate{
 				View:     d.view(),
 				Solution: d.viewSolution(),
+				Answer:   d.answer(),
 				Done:     false,
 			}
 		}
@@ -120,62 +99,35 @@ func (d *Day5) Run(updates chan<- DayUpdate) error {
 	updates <- DayUpdate{
 		View:     d.view(),
 		Solution: d.viewSolution(),
+		Answer:   d.answer(),
 		Done:     true,
 	}
 	return nil
 }
 
 func (d *Day5) part1() {
-NEXTID:
+	fresh := NewIntervalSet(d.inputRanges...)
 	for _, id := range d.inputIDs {
-		for _, idRange := range d.inputRanges {
-			if id >= idRange.low && id <= idRange.high {
-				d.solution1++
-				continue NEXTID
-			}
+		if fresh.Contains(id) {
+			d.solution1++
 		}
 	}
 }
 
 func (d *Day5) part2(update func()) {
-	// assume all ranges are valid
-	d.mergedRanges = make(map[int]bool)
-	d.ranges = make([]int64Range, len(d.inputRanges))
-	copy(d.ranges, d.inputRanges)
-	slices.SortFunc(d.ranges, func(a, b int64Range) int {
-		return int(a.low - b.low)
-	})
-
-	// keep trying until we no longer merge any ranges
-	mergedRange := true
-	for {
-		if !mergedRange {
-			break
-		}
-		mergedRange = false
-		ranges := make([]int64Range, len(d.ranges))
-		copy(ranges, d.ranges)
-		// empty out the ranges store
-		d.ranges = d.ranges[:0]
-	NEXTRANGE:
-		for _, idRange := range ranges {
-			// update each looop
-			update()
-
-			// find overlap with existing ranges
-			for i, r := range d.ranges {
-				merged := checkIn64RangeOverlap(idRange, r)
-				if merged == (int64Range{}) {
-					continue
-				}
-				d.ranges[i] = merged
-				d.mergedRanges[i] = true
-				continue NEXTRANGE
-			}
+	d.mergedRanges = make(map[int64]bool)
+	d.ranges = &IntervalSet[int64]{}
 
-			// add this to the ranges to track
-			d.ranges = append(d.ranges, idRange)
+	// insert in order so the visualization grows from the lowest id up
+	ranges := slices.Clone(d.inputRanges)
+	slices.SortFunc(ranges, func(a, b Interval[int64]) int {
+		return cmp.Compare(a.Low, b.Low)
+	})
+	for _, idRange := range ranges {
+		if merged, ok := d.ranges.Insert(idRange); ok {
+			d.mergedRanges[merged.Low] = true
 		}
+		update()
 	}
 
 	// compute distance inclusive of the range
@@ -183,48 +135,21 @@ func (d *Day5) part2(update func()) {
 }
 
 func (d *Day5) calcSolution2() {
-	d.solution2 = 0
-	for _, r := range d.ranges {
-		d.solution2 += r.high - r.low + 1
-	}
+	d.solution2 = d.ranges.Length()
 }
 
-// checkIn64RangeOverlap checks if two int64 ranges overlap
-// returns empty int64Range if no overlap
-func checkIn64RangeOverlap(r1, r2 int64Range) int64Range {
-
-	// 16 - 20 followed by 12 - 18 looks like this
-	//	           16 17 18 19 20
-	// 12 13 14 15 16 17 18
-	// new range is 12 - 20
-
-	if r1.low >= r2.low && r1.high <= r2.high {
-		// r1 falls within r2
-		return r2
-	}
-
-	if r2.low >= r1.low && r2.high <= r1.high {
-		// r2 falls within r1
-		return r1
-	}
-
-	if r1.high+1 < r2.low || r1.low-1 > r2.high {
-		// if r2 is 10..15 and r1 is n..8 or 17..n, no overlap
-		return int64Range{}
+// KeyBindings lets the visualizer toggle between the range list and the grid
+func (d *Day5) KeyBindings() []KeyBinding {
+	return []KeyBinding{
+		{
+			Binding: key.NewBinding(key.WithKeys("g"), key.WithHelp("g", "toggle grid")),
+			Handle:  func() { d.showGrid.Store(!d.showGrid.Load()) },
+		},
 	}
+}
 
-	// if we got here, we have an overlap
-	merged := r2
-	if r1.low > r2.low {
-		// if r is 10..15 and we are 12..n, our high becomes their new high
-		merged.high = r1.high
-	}
-	if r1.high < r2.high {
-		// if r is 10..15 and we are n..12, our low becomes their new low
-		merged.low = r1.low
-	}
-	// return the merged result
-	return merged
+func (d *Day5) View() string {
+	return d.view()
 }
 
 func (d *Day5) view() string {
@@ -232,18 +157,23 @@ func (d *Day5) view() string {
 		// return ""
 	}
 
+	if d.showGrid.Load() {
+		return d.viewGrid()
+	}
+
 	var sb strings.Builder
-	for i, r := range d.ranges {
+	for _, r := range d.ranges.All() {
 		var highStr string
-		if d.mergedRanges[i] {
-			highStr = visitedStyle.Render(strconv.FormatInt(r.high, 10))
+		if d.mergedRanges[r.Low] {
+			highStr = d.Theme.Visited.Render(strconv.FormatInt(r.High, 10))
 		} else {
-			highStr = data2Style.Render(strconv.FormatInt(r.high, 10))
+			highStr = d.Theme.Data2.Render(strconv.FormatInt(r.High, 10))
 		}
-		sb.WriteString(fmt.Sprintf("%s..%s → valid ids: %s\n",
-			data1Style.Render(strconv.FormatInt(r.low, 10)),
+		sb.WriteString(fmt.Sprintf("%s..%s %s valid ids: %s\n",
+			d.Theme.Data1.Render(strconv.FormatInt(r.Low, 10)),
 			highStr,
-			correctResultStyle.Render(strconv.FormatInt(r.high-r.low+1, 10)),
+			d.Theme.Glyph("→", "->"),
+			d.Theme.Correct.Render(strconv.FormatInt(r.Length(), 10)),
 		))
 	}
 	return sb.String()
@@ -256,16 +186,20 @@ func (d *Day5) viewGrid() string {
 
 	d.buildGrid()
 
-	return RenderBrailleWithColor(d.grid, DensityColor)
+	return d.Theme.BrailleDensity(d.grid)
 }
 
 func (d *Day5) viewSolution() string {
 	return fmt.Sprintf("solution1: %s, solution2: %s",
-		solutionStyle.Render(strconv.Itoa(d.solution1)),
-		solutionStyle.Render(strconv.FormatInt(d.solution2, 10)),
+		d.Theme.Solution.Render(strconv.Itoa(d.solution1)),
+		d.Theme.Solution.Render(strconv.FormatInt(d.solution2, 10)),
 	)
 }
 
+func (d *Day5) answer() Answer {
+	return Answer{Part1: int64(d.solution1), Part2: int64(d.solution2)}
+}
+
 func (d *Day5) buildGrid() {
 
 	// clear the grid
@@ -275,24 +209,24 @@ func (d *Day5) buildGrid() {
 		}
 	}
 
-	span := d.inputRange.high - d.inputRange.low
+	span := d.inputRange.High - d.inputRange.Low
 
 	numCells := int64(gridWidth * gridHeight)
 	cellsPerID := float64(numCells) / float64(span)
 
 	// For each valid range, map it into [0, numCells) and mark cells on.
-	for _, r := range d.ranges {
+	for _, r := range d.ranges.All() {
 		// Clamp to the global input range, just in case
-		low := max(r.low, d.inputRange.low)
-		high := min(r.high, d.inputRange.high)
+		low := max(r.Low, d.inputRange.Low)
+		high := min(r.High, d.inputRange.High)
 
 		// If you treat ranges as [low, high), then high <= low is empty.
 		if high <= low {
 			continue
 		}
 
-		startOff := low - d.inputRange.low // offset from global low
-		endOff := high - d.inputRange.low
+		startOff := low - d.inputRange.Low // offset from global low
+		endOff := high - d.inputRange.Low
 
 		// Map offsets to linear cell indices
 		startIdx := int(float64(startOff) * cellsPerID)
@@ -331,11 +265,11 @@ func (d *Day5) buildGrid() {
 
 // part2_sollniss is a solution found on reddit from @sollniss. Wow, that's much faster than mine. :)
 // putting here for benchmarking
-func (d *Day5) part2_sollniss(ranges []int64Range) int {
+func (d *Day5) part2_sollniss(ranges []Interval[int64]) int {
 	// https://github.com/sollniss/aoc2025/blob/14c88f9798582e0c187504d75f9d4ffeb137abc3/day5/main.go#L153-L164
 
-	slices.SortFunc(ranges, func(a, b int64Range) int {
-		if a.low < b.low {
+	slices.SortFunc(ranges, func(a, b Interval[int64]) int {
+		if a.Low < b.Low {
 			return -1
 		} else {
 			return 1
@@ -345,15 +279,15 @@ func (d *Day5) part2_sollniss(ranges []int64Range) int {
 	res := 0
 	var curr int64
 	for _, r := range ranges {
-		if curr > r.high {
+		if curr > r.High {
 			continue
 		}
-		from := max(curr, r.low)
-		if from <= r.high {
-			res += int(r.high-from) + 1
+		from := max(curr, r.Low)
+		if from <= r.High {
+			res += int(r.High-from) + 1
 		}
 
-		curr = r.high + 1
+		curr = r.High + 1
 	}
 
 	return res
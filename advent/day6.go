@@ -2,9 +2,11 @@ package advent
 
 import (
 	"fmt"
-	"os"
+	"io"
 	"strconv"
 	"strings"
+
+	"github.com/sirgwain/advent-of-code-2025/advent/parse"
 )
 
 type Day6 struct {
@@ -20,40 +22,70 @@ type Day6 struct {
 	solution2       int
 }
 
+// day6Operator is how the numbers in a problem are combined, * or +
+type day6Operator string
+
+func (op *day6Operator) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "*", "+":
+		*op = day6Operator(text)
+		return nil
+	}
+	return fmt.Errorf("operators must be * or +")
+}
+
+var (
+	day6NumbersFormat   = parse.MustCompile[[]int]("{: }")
+	day6OperatorsFormat = parse.MustCompile[[]day6Operator]("{: }")
+)
+
 func (d *Day6) Day() int {
 	return 6
 }
 
-// Init loads in the input from the file and initializes the Day
-func (d *Day6) Init(filename string, options *Options) (err error) {
+// Init loads in the input and initializes the Day
+func (d *Day6) Init(r io.Reader, options *Options) (err error) {
 	d.Options = options
-	content, err := os.ReadFile(filename)
+	defer func() { err = asParseError(d.Day(), err) }()
+	in, err := readInput(r, d.InputName)
+	if err != nil {
+		return err
+	}
 
+	// rows of numbers with a row of operators at the bottom
+	//  45 64  387 23
+	//   6 98  215 314
+	// *   +   *   +
+	if len(in.Lines) < 2 {
+		return in.Errorf("expected rows of numbers and a row of operators, found %d lines", len(in.Lines))
+	}
+	last := in.Lines[len(in.Lines)-1]
+	operators, err := day6OperatorsFormat.ParseLine(last)
 	if err != nil {
 		return err
 	}
-	lines := strings.Split(string(content), "\n")
-	d.input = make([][]int, len(lines)-1)
-	d.board = make([][]byte, 0, len(lines))
-	for i, line := range lines {
-		d.board = append(d.board, []byte(line)) // part 2, build a byte board
-		split := strings.Split(string(line), " ")
-		for _, s := range split {
-			trimmed := strings.TrimSpace(s)
-			if trimmed == "" {
-				continue
-			}
-			if trimmed == "*" || trimmed == "+" {
-				// operand
-				d.inputOperations = append(d.inputOperations, trimmed)
-				continue
-			}
-			num, err := strconv.Atoi(trimmed)
-			if err != nil {
-				return fmt.Errorf("error parsing number: %s", trimmed)
-			}
-			d.input[i] = append(d.input[i], num)
+	for _, op := range operators {
+		d.inputOperations = append(d.inputOperations, string(op))
+	}
+
+	width := 0
+	for _, line := range in.Lines[:len(in.Lines)-1] {
+		nums, err := day6NumbersFormat.ParseLine(line)
+		if err != nil {
+			return err
 		}
+		if len(nums) != len(operators) {
+			return line.Errorf(0, "expected %d numbers, one for each operator, found %d", len(operators), len(nums))
+		}
+		d.input = append(d.input, nums)
+		width = max(width, len(line.Text))
+	}
+
+	// part 2 reads the numbers down the columns, so build a byte board with every row the same width
+	width = max(width, len(last.Text))
+	d.board = make([][]byte, 0, len(in.Lines))
+	for _, line := range in.Lines {
+		d.board = append(d.board, []byte(line.Text+strings.Repeat(" ", width-len(line.Text))))
 	}
 
 	return nil
@@ -67,6 +99,7 @@ func (d *Day6) Run(updates chan<- DayUpdate) error {
 	updates <- DayUpdate{
 		View:     d.view(),
 		Solution: d.viewSolution(),
+		Answer:   d.answer(),
 		Done:     true,
 	}
 	return nil
@@ -134,6 +167,7 @@ NEXTPROBLEM:
 				updates <- DayUpdate{
 					View:     d.view(),
 					Solution: d.viewSolution(),
+					Answer:   d.answer(),
 					Done:     false,
 				}
 			}
@@ -185,8 +219,8 @@ func (d *Day6) view() string {
 	}
 
 	line := fmt.Sprintf("%s = %s\n",
-		data1Style.Render(d.problem),
-		correctResultStyle.Render(strconv.Itoa(d.problemSolution)),
+		d.Theme.Data1.Render(d.problem),
+		d.Theme.Correct.Render(strconv.Itoa(d.problemSolution)),
 	)
 	d.viewStr.WriteString(line)
 	return d.viewStr.String()
@@ -194,7 +228,11 @@ func (d *Day6) view() string {
 
 func (d *Day6) viewSolution() string {
 	return fmt.Sprintf("solution1: %s solution2: %s",
-		solutionStyle.Render(strconv.Itoa(d.solution1)),
-		solutionStyle.Render(strconv.Itoa(d.solution2)),
+		d.Theme.Solution.Render(strconv.Itoa(d.solution1)),
+		d.Theme.Solution.Render(strconv.Itoa(d.solution2)),
 	)
 }
+
+func (d *Day6) answer() Answer {
+	return Answer{Part1: int64(d.solution1), Part2: int64(d.solution2)}
+}
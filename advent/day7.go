@@ -2,60 +2,78 @@ package advent
 
 import (
 	"fmt"
+	"io"
 	"strconv"
-	"strings"
-)
-
-// prerender some styled characters
-var (
-	renderedStart = data1Style.Render(" S ")
-	renderedBeam  = correctResultStyle.Render(" | ")
-	renderedSplit = wallStyle.Render(" ^ ")
 )
 
 type Day7 struct {
 	*Options
-	board              [][]rune
+	board              *Grid[rune]
 	splits             map[Point]bool
 	solutionsFromSplit map[Point]int64
 
 	solution1 int
 	solution2 int64
+
+	// prerender some styled characters
+	renderedStart string
+	renderedBeam  string
+	renderedSplit string
 }
 
 func (d *Day7) Day() int {
 	return 7
 }
 
-// Init loads in the input from the file and initializes the Day
-func (d *Day7) Init(filename string, options *Options) (err error) {
+// Init loads in the input and initializes the Day
+func (d *Day7) Init(r io.Reader, options *Options) (err error) {
 	d.Options = options
-	d.board, err = ReadInputAsRunes(filename)
+	defer func() { err = asParseError(d.Day(), err) }()
+	in, err := readInput(r, d.InputName)
 	if err != nil {
 		return err
 	}
+	// the beam starts at the S and splits at each ^
+	if d.board, err = parseGrid(in, ".S^"); err != nil {
+		return err
+	}
+	if _, ok := d.board.Find('S'); !ok {
+		// the beam comes in from the top, so that's where the S belongs
+		return in.Lines[0].Errorf(0, "no S to start the beam from")
+	}
 
 	d.splits = make(map[Point]bool)
 	d.solutionsFromSplit = make(map[Point]int64)
+
+	d.renderedStart = d.Theme.Data1.Render(" S ")
+	d.renderedBeam = d.Theme.Correct.Render(" | ")
+	d.renderedSplit = d.Theme.Wall.Render(" ^ ")
 	return nil
 }
 
 func (d *Day7) Run(updates chan<- DayUpdate) error {
 
-	d.fireBeams(updates)
+	if err := d.fireBeams(updates); err != nil {
+		return err
+	}
 
 	updates <- DayUpdate{
 		View:     d.view(),
 		Solution: d.viewSolution(),
+		Answer:   d.answer(),
 		Done:     true,
 	}
 	return nil
 }
 
-func (d *Day7) fireBeams(updates chan<- DayUpdate) {
+func (d *Day7) fireBeams(updates chan<- DayUpdate) error {
 	// starting from the S, fire a beam
-	x, y := FindValue(d.board, 'S')
-	d.solution2 = d.fireBeam(x, y+1, updates)
+	start, ok := d.board.Find('S')
+	if !ok {
+		return fmt.Errorf("no start found in data")
+	}
+	d.solution2 = d.fireBeam(start.X, start.Y+1, updates)
+	return nil
 }
 
 func (d *Day7) fireBeam(x, y int, updates chan<- DayUpdate) int64 {
@@ -64,12 +82,13 @@ func (d *Day7) fireBeam(x, y int, updates chan<- DayUpdate) int64 {
 		return d.solutionsFromSplit[Point{x, y}]
 	}
 
-	if y >= len(d.board) {
+	if y >= d.board.Height() {
 		// finished the board, record it and move on
 		if !d.Quiet {
 			updates <- DayUpdate{
 				View:     d.view(),
 				Solution: d.viewSolution(),
+				Answer:   d.answer(),
 				Done:     false,
 			}
 		}
@@ -77,7 +96,7 @@ func (d *Day7) fireBeam(x, y int, updates chan<- DayUpdate) int64 {
 		return 1
 	}
 
-	val := GetBoardValue(x, y, d.board)
+	val := d.board.At(Point{x, y})
 	if val == '^' {
 		// found split
 		d.splits[Point{x, y}] = true
@@ -89,7 +108,7 @@ func (d *Day7) fireBeam(x, y int, updates chan<- DayUpdate) int64 {
 		d.solutionsFromSplit[Point{x, y}] += r
 		return l + r
 	} else {
-		d.board[y][x] = '|'
+		d.board.Set(Point{x, y}, '|')
 		s := d.fireBeam(x, y+1, updates)
 		return s
 	}
@@ -99,33 +118,31 @@ func (d *Day7) view() string {
 	if d.Quiet {
 		return ""
 	}
-	var sb strings.Builder
-	for y, line := range d.board {
-		for x, r := range line {
-			switch r {
-			case 'S':
-				sb.WriteString(renderedStart)
-			case '^':
-				solutionsfromPosition := d.solutionsFromSplit[Point{x, y}]
-				if d.splits[Point{x, y}] {
-					sb.WriteString(visitedStyle.Render(fmt.Sprintf("%3d", solutionsfromPosition)))
-				} else {
-					sb.WriteString(renderedSplit)
-				}
-			case '|':
-				sb.WriteString(renderedBeam)
-			default:
-				sb.WriteString(" . ")
+	return d.board.Render(func(p Point, r rune) string {
+		switch r {
+		case 'S':
+			return d.renderedStart
+		case '^':
+			if d.splits[p] {
+				solutionsfromPosition := d.solutionsFromSplit[p]
+				return d.Theme.Visited.Render(fmt.Sprintf("%3d", solutionsfromPosition))
 			}
+			return d.renderedSplit
+		case '|':
+			return d.renderedBeam
+		default:
+			return " . "
 		}
-		sb.WriteString("\n")
-	}
-	return sb.String()
+	})
 }
 
 func (d *Day7) viewSolution() string {
 	return fmt.Sprintf("solution1: %s solution2: %s",
-		solutionStyle.Render(strconv.Itoa(d.solution1)),
-		solutionStyle.Render(strconv.FormatInt(d.solution2, 10)),
+		d.Theme.Solution.Render(strconv.Itoa(d.solution1)),
+		d.Theme.Solution.Render(strconv.FormatInt(d.solution2, 10)),
 	)
 }
+
+func (d *Day7) answer() Answer {
+	return Answer{Part1: int64(d.solution1), Part2: int64(d.solution2)}
+}
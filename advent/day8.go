@@ -1,52 +1,33 @@
 package advent
 
 import (
-	"bufio"
-	"cmp"
 	"fmt"
-	"maps"
-	"os"
-	"slices"
+	"io"
 	"strconv"
 	"strings"
+
+	"github.com/sirgwain/advent-of-code-2025/advent/parse"
+	"github.com/sirgwain/advent-of-code-2025/advent/unionfind"
 )
 
 type Day8 struct {
 	*Options
-	input     [][3]int
+	input     []Point3
 	closestN  int
+	largest   []int // the sizes of the largest circuits after closestN pairs
 	solution1 int
 	solution2 int
 }
 
 type node struct {
-	point   [3]int
-	index   int
-	circuit int
-}
-
-type pair struct {
-	n1   *node
-	n2   *node
-	dist int64
-}
-
-type circuit struct {
-	circuit int
-	nodes   []*node
+	point Point3
+	index int
 }
 
 func (n *node) String() string {
 	return fmt.Sprintf("%d %v", n.index, n.point)
 }
 
-func distSquared(a, b [3]int) int64 {
-	dx := a[0] - b[0]
-	dy := a[1] - b[1]
-	dz := a[2] - b[2]
-	return int64(dx*dx + dy*dy + dz*dz)
-}
-
 func (d *Day8) Day() int {
 	return 8
 }
@@ -61,146 +42,76 @@ func (d *Day8) Run(updates chan<- DayUpdate) error {
 		}
 	}
 
-	// compute all distances from each junction box to every other junction box, as pairs
-	pairs := []pair{}
-	for i, n1 := range nodes {
-		for j := i + 1; j < len(nodes); j++ {
-			n2 := nodes[j]
-			pairs = append(pairs, pair{
-				n1:   n1,
-				n2:   n2,
-				dist: distSquared(n1.point, n2.point),
-			})
-		}
-	}
-
-	// sort pairs by distance
-	slices.SortFunc(pairs, func(p1, p2 pair) int { return cmp.Compare(p1.dist, p2.dist) })
+	// each junction box starts in its own circuit
+	circuits := unionfind.New(nodes...)
 
-	circuits := map[int]*circuit{}
-
-	for count := range len(pairs) {
+	// walk the pairs of junction boxes from closest to farthest. They're found as we go,
+	// so we only look at as many pairs as it takes to join everything into one circuit.
+	count := 0
+	for pair := range ClosestPairs(d.input) {
 		if count == d.closestN {
 			d.recordPart1(circuits)
 		}
-		pair := pairs[count]
-		fmt.Printf("%d: next closest node %v => %v %d\n",
-			count,
-			data1Style.Render(pair.n1.String()),
-			data2Style.Render(pair.n2.String()),
-			pair.dist,
-		)
-
-		n1 := pair.n1
-		n2 := pair.n2
-
-		if n1.circuit != 0 && n1.circuit == n2.circuit {
-			fmt.Printf("part of same circuit %s", correctResultStyle.Render(strconv.Itoa(n1.circuit)))
-		} else if n1.circuit != 0 && n2.circuit != 0 {
-			// merge n2 circuit into n1
-			circuits[n1.circuit].nodes = append(circuits[n1.circuit].nodes, (circuits[n2.circuit].nodes)...)
-			n2Circuit := n2.circuit
-			for _, n := range circuits[n2.circuit].nodes {
-				n.circuit = n1.circuit
-			}
-			delete(circuits, n2Circuit)
-			fmt.Printf("already part of existing circuit %s", correctResultStyle.Render(strconv.Itoa(n1.circuit)))
-		} else if n1.circuit != 0 {
-			// n1 already in a circuit, join n2
-			n2.circuit = n1.circuit
-			circuits[n1.circuit].nodes = append(circuits[n1.circuit].nodes, n2)
-			fmt.Printf("adding to existing circuit %s", correctResultStyle.Render(strconv.Itoa(n1.circuit)))
-		} else if n2.circuit != 0 {
-			// n2 already in a circuit, join n1
-			n1.circuit = n2.circuit
-			circuits[n2.circuit].nodes = append(circuits[n2.circuit].nodes, n1)
-			fmt.Printf("adding to existing circuit %s", correctResultStyle.Render(strconv.Itoa(n2.circuit)))
-		} else {
-			// form new circuit
-			n1.circuit = count + 1
-			n2.circuit = n1.circuit
-			circuits[n1.circuit] = &circuit{
-				circuit: n1.circuit,
-				nodes:   []*node{n1, n2},
+		count++
+
+		n1 := nodes[pair.A]
+		n2 := nodes[pair.B]
+		joined := circuits.Union(n1, n2)
+		done := circuits.Count() == 1
+		if done {
+			// found the last pair
+			d.solution2 = n1.point.X * n2.point.X
+		}
+
+		if !d.Quiet {
+			updates <- DayUpdate{
+				View:     d.viewPair(count-1, n1, n2, pair.DistSquared, joined, done, circuits),
+				Solution: d.viewSolution(),
+				Answer:   d.answer(),
+				Done:     false,
 			}
-			fmt.Printf("creating new circuit %s", correctResultStyle.Render(strconv.Itoa(n1.circuit)))
 		}
-		fmt.Printf(": %s boxes, (%s)\n\n",
-			solutionStyle.Render(strconv.Itoa(len(circuits[n1.circuit].nodes))),
-			data2Style.Render(fmt.Sprintf("%s", circuits[n1.circuit].nodes)))
 
-		if len(circuits) == 1 && len(circuits[n1.circuit].nodes) == len(d.input) {
-			// found the last pair
-			fmt.Printf("found final pair %s %s",
-				data1Style.Render(n1.String()),
-				data2Style.Render(n2.String()),
-			)
-			d.solution2 = n1.point[0] * n2.point[0]
+		if done {
 			break
 		}
-
+	}
+	if count <= d.closestN {
+		// everything joined up before closestN pairs, the rest wouldn't change the circuits
+		d.recordPart1(circuits)
 	}
 
 	updates <- DayUpdate{
 		View:     d.view(),
 		Solution: d.viewSolution(),
+		Answer:   d.answer(),
 		Done:     true,
 	}
 	return nil
 }
 
 // after N steps, record part1's score
-func (d *Day8) recordPart1(circuits map[int]*circuit) {
+func (d *Day8) recordPart1(circuits *unionfind.DisjointSet[*node]) {
+	d.largest = circuits.Sizes()[:min(3, circuits.Count())]
 	d.solution1 = 1
-
-	sortedCircuits := slices.Collect(maps.Values(circuits))
-	slices.SortFunc(sortedCircuits, func(c1, c2 *circuit) int { return cmp.Compare(len(c2.nodes), len(c1.nodes)) })
-	for i := range 3 {
-		circuit := sortedCircuits[i]
-		if len(circuit.nodes) == 0 {
-			continue
-		}
-		fmt.Printf("c: %s -> %s boxes, %s\n",
-			correctResultStyle.Render(strconv.Itoa(circuit.circuit)),
-			solutionStyle.Render(strconv.Itoa(len(circuit.nodes))),
-			data2Style.Render(fmt.Sprintf("%s", circuit.nodes)))
-		d.solution1 *= len(circuit.nodes)
+	for _, size := range d.largest {
+		d.solution1 *= size
 	}
 }
 
-// Init loads in the input from the file and initializes the Day
-func (d *Day8) Init(filename string, options *Options) (err error) {
+var day8Format = parse.MustCompile[Point3]("{X},{Y},{Z}")
+
+// Init loads in the input and initializes the Day
+func (d *Day8) Init(r io.Reader, options *Options) (err error) {
 	d.Options = options
+	defer func() { err = asParseError(d.Day(), err) }()
 
-	file, err := os.Open(filename)
+	in, err := readInput(r, d.InputName)
 	if err != nil {
-		return fmt.Errorf("error opening file: %w", err)
+		return err
 	}
-	defer file.Close()
-
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := scanner.Text()
-		nums := strings.Split(line, ",")
-
-		x, err := strconv.Atoi(nums[0])
-		if err != nil {
-			return fmt.Errorf("error parsing number on line: %s", line)
-		}
-		y, err := strconv.Atoi(nums[1])
-		if err != nil {
-			return fmt.Errorf("error parsing number on line: %s", line)
-		}
-		z, err := strconv.Atoi(nums[2])
-		if err != nil {
-			return fmt.Errorf("error parsing number on line: %s", line)
-		}
-
-		d.input = append(d.input, [3]int{x, y, z})
-	}
-
-	if err := scanner.Err(); err != nil {
-		return fmt.Errorf("error reading file: %w", err)
+	if d.input, err = day8Format.Records(in); err != nil {
+		return err
 	}
 
 	// different data requires different "n closest"
@@ -209,19 +120,54 @@ func (d *Day8) Init(filename string, options *Options) (err error) {
 		d.closestN = 10
 	}
 
-	return err
+	return nil
+}
+
+// viewPair describes joining the step'th closest pair of junction boxes
+func (d *Day8) viewPair(step int, n1, n2 *node, distSquared int64, joined, done bool, circuits *unionfind.DisjointSet[*node]) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%d: next closest node %v => %v %d\n",
+		step,
+		d.Theme.Data1.Render(n1.String()),
+		d.Theme.Data2.Render(n2.String()),
+		distSquared,
+	))
+	if joined {
+		sb.WriteString(fmt.Sprintf("joined circuit %s", d.Theme.Correct.Render(circuits.Find(n1).String())))
+	} else {
+		sb.WriteString(fmt.Sprintf("part of same circuit %s", d.Theme.Correct.Render(circuits.Find(n1).String())))
+	}
+	sb.WriteString(fmt.Sprintf(": %s boxes", d.Theme.Solution.Render(strconv.Itoa(circuits.Size(n1)))))
+	if done {
+		sb.WriteString(fmt.Sprintf("\nfound final pair %s %s",
+			d.Theme.Data1.Render(n1.String()),
+			d.Theme.Data2.Render(n2.String()),
+		))
+	}
+	return sb.String()
 }
 
 func (d *Day8) view() string {
 	if d.Quiet {
 		return ""
 	}
-	return ""
+	// the largest circuits part1 multiplies together
+	var sb strings.Builder
+	for i, size := range d.largest {
+		sb.WriteString(fmt.Sprintf("c: %s -> %s boxes\n",
+			d.Theme.Correct.Render(strconv.Itoa(i+1)),
+			d.Theme.Solution.Render(strconv.Itoa(size))))
+	}
+	return sb.String()
 }
 
 func (d *Day8) viewSolution() string {
 	return fmt.Sprintf("solution1: %s solution2: %s",
-		solutionStyle.Render(strconv.Itoa(d.solution1)),
-		solutionStyle.Render(strconv.Itoa(d.solution2)),
+		d.Theme.Solution.Render(strconv.Itoa(d.solution1)),
+		d.Theme.Solution.Render(strconv.Itoa(d.solution2)),
 	)
 }
+
+func (d *Day8) answer() Answer {
+	return Answer{Part1: int64(d.solution1), Part2: int64(d.solution2)}
+}
@@ -0,0 +1,74 @@
+package advent
+
+import (
+	"strings"
+	"testing"
+)
+
+// the example from the puzzle
+var day8Example = []string{
+	"162,817,812", "57,618,57", "906,360,560", "592,479,940", "352,342,300",
+	"466,668,158", "542,29,236", "431,825,988", "739,650,466", "52,470,668",
+	"216,146,977", "819,987,18", "117,168,530", "805,96,715", "346,949,466",
+	"970,615,88", "941,993,340", "862,61,35", "984,92,344", "425,690,689",
+}
+
+func TestDay8_Run(t *testing.T) {
+	d := Day8{}
+	if err := d.Init(strings.NewReader(strings.Join(day8Example, "\n")), NewRun(WithQuiet(true))); err != nil {
+		t.Fatalf("Day8.Init() error = %v", err)
+	}
+	updates := make(chan DayUpdate, 1)
+	if err := d.Run(updates); err != nil {
+		t.Fatalf("Day8.Run() error = %v", err)
+	}
+	if got, want := (<-updates).Answer, (Answer{Part1: 40, Part2: 25272}); got != want {
+		t.Errorf("Day8.Run() = %v, want %v", got, want)
+	}
+}
+
+func TestDay8_RunSteps(t *testing.T) {
+	d := Day8{}
+	if err := d.Init(strings.NewReader(strings.Join(day8Example, "\n")), NewRun(WithTheme(themes[ThemeNoColor]))); err != nil {
+		t.Fatalf("Day8.Init() error = %v", err)
+	}
+
+	// each pair is sent as an update rather than printed, so it doesn't draw over the tui
+	var steps []DayUpdate
+	answer, err := runDay(&d, func(u DayUpdate) { steps = append(steps, u) })
+	if err != nil {
+		t.Fatalf("Day8.Run() error = %v", err)
+	}
+	if want := (Answer{Part1: 40, Part2: 25272}); answer != want {
+		t.Errorf("Day8.Run() = %v, want %v", answer, want)
+	}
+
+	// the example joins everything on its 29th pair, then sends the largest circuits
+	if len(steps) != 30 {
+		t.Fatalf("Day8.Run() sent %d updates, want 30", len(steps))
+	}
+	if got := steps[0].View; !strings.HasPrefix(got, "0: next closest node 0 162, 817, 812 => 19 425, 690, 689") {
+		t.Errorf("Day8.Run() first update = %q, want the closest pair", got)
+	}
+	if got := steps[28].View; !strings.Contains(got, "found final pair") {
+		t.Errorf("Day8.Run() last pair = %q, want the final pair", got)
+	}
+	if got, want := steps[29].View, "c: 1 -> 5 boxes\nc: 2 -> 4 boxes\nc: 3 -> 2 boxes\n"; got != want {
+		t.Errorf("Day8.Run() final view = %q, want %q", got, want)
+	}
+}
+
+func TestDay8_RunJoinedEarly(t *testing.T) {
+	// five boxes join into one circuit well before the 1000 pairs part1 connects
+	d := Day8{}
+	if err := d.Init(strings.NewReader("0,0,0\n1,0,0\n2,0,0\n3,0,0\n10,0,0\n"), NewRun(WithQuiet(true))); err != nil {
+		t.Fatalf("Day8.Init() error = %v", err)
+	}
+	updates := make(chan DayUpdate, 1)
+	if err := d.Run(updates); err != nil {
+		t.Fatalf("Day8.Run() error = %v", err)
+	}
+	if got, want := (<-updates).Answer, (Answer{Part1: 5, Part2: 30}); got != want {
+		t.Errorf("Day8.Run() = %v, want %v", got, want)
+	}
+}
@@ -1,86 +1,80 @@
 package advent
 
 import (
-	"bufio"
 	"fmt"
-	"math"
-	"os"
+	"io"
+	"slices"
 	"strconv"
-	"strings"
 
-	"github.com/charmbracelet/lipgloss"
-	"github.com/sirgwain/advent-of-code-2025/advent/color"
+	"github.com/sirgwain/advent-of-code-2025/advent/geometry"
+	"github.com/sirgwain/advent-of-code-2025/advent/parse"
 )
 
-var (
-	renderedRedSquare            = lipgloss.NewStyle().Foreground(color.BloodRed52).Render("#")
-	renderedHighlightedRedSquare = lipgloss.NewStyle().Foreground(color.BloodRed52).Background(color.VioletsAreBlue105).Render("#")
-	renderedGreenSquare          = lipgloss.NewStyle().Foreground(color.BrightGreen82).Render("X")
+// the largest board Day9 draws cell by cell
+const (
+	day9MaxViewWidth  = 200
+	day9MaxViewHeight = 100
 )
 
 type Day9 struct {
 	*Options
-	input          [][2]int
+	input          []Point
 	poly           []Point
-	board          [][]byte
-	min            Point
-	max            Point
+	board          *SparseGrid[byte]
 	p1             Point
 	p2             Point
+	polygon        geometry.Polygon
+	mask           *polygonMask
+	validateByRows bool // check rectangles with validateRectangleByRows instead of the mask
 	validRectangle *bool
 	solution1      int
 	solution2      int
+
+	// prerender some styled characters
+	renderedRedSquare            string
+	renderedHighlightedRedSquare string
+	renderedGreenSquare          string
 }
 
 func (d *Day9) Day() int {
 	return 9
 }
 
-// Init loads in the input from the file and initializes the Day
-func (d *Day9) Init(filename string, options *Options) (err error) {
-	d.Options = options
-
-	file, err := os.Open(filename)
-	if err != nil {
-		return fmt.Errorf("error opening file: %w", err)
-	}
-	defer file.Close()
+var day9Format = parse.MustCompile[Point]("{X},{Y}")
 
-	d.min = Point{math.MaxInt, math.MaxInt}
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := scanner.Text()
-		nums := strings.Split(line, ",")
-
-		x, err := strconv.Atoi(nums[0])
-		if err != nil {
-			return fmt.Errorf("error parsing number on line: %s", line)
-		}
-		y, err := strconv.Atoi(nums[1])
-		if err != nil {
-			return fmt.Errorf("error parsing number on line: %s", line)
-		}
+// Init loads in the input and initializes the Day
+func (d *Day9) Init(r io.Reader, options *Options) (err error) {
+	d.Options = options
+	defer func() { err = asParseError(d.Day(), err) }()
+	d.renderedRedSquare = d.Theme.Wall.Render("#")
+	d.renderedHighlightedRedSquare = d.Theme.Highlight.Render("#")
+	d.renderedGreenSquare = d.Theme.Box.Render("X")
 
-		d.input = append(d.input, [2]int{x, y})
-		d.min = Point{min(x, d.min.X), min(y, d.min.Y)}
-		d.max = Point{max(x, d.max.X), max(y, d.max.Y)}
+	// part2 checks rectangles against the mask, unless the params ask for the original row by row check
+	switch validate := d.Param("validate", "mask"); validate {
+	case "mask":
+		d.validateByRows = false
+	case "rows":
+		d.validateByRows = true
+	default:
+		return fmt.Errorf("unknown validate param %q, expected mask or rows", validate)
 	}
 
-	if err := scanner.Err(); err != nil {
-		return fmt.Errorf("error reading file: %w", err)
+	in, err := readInput(r, d.InputName)
+	if err != nil {
+		return err
 	}
-
+	d.input, err = day9Format.Records(in)
 	return err
 }
 
 func (d *Day9) Run(updates chan<- DayUpdate) error {
 
-	d.board = MakeBoard[byte](d.max.X+1, d.max.Y+1)
+	d.board = NewSparseGrid[byte]()
 	d.poly = make([]Point, len(d.input))
-	for i, p := range d.input {
-		point := Point{p[0], p[1]}
+	for i, point := range d.input {
 		d.poly[i] = point
-		d.board[point.Y][point.X] = 0x01
+		d.board.Set(point, 0x01)
 
 		var p1 Point
 		if i > 0 {
@@ -88,27 +82,31 @@ func (d *Day9) Run(updates chan<- DayUpdate) error {
 			p1 = d.poly[i-1]
 		} else {
 			// for the first point in the polygon, draw a line from the last point
-			p1 = Point{d.input[len(d.input)-1][0], d.input[len(d.input)-1][1]}
+			p1 = d.input[len(d.input)-1]
 		}
 		// draw line from the previous point
 		x1 := min(p1.X, point.X)
 		x2 := max(p1.X, point.X)
 		for x := x1 + 1; x < x2; x++ {
-			d.board[point.Y][x] |= 0x02
+			p := Point{x, point.Y}
+			d.board.Set(p, d.board.At(p)|0x02)
 		}
 		y1 := min(p1.Y, point.Y)
 		y2 := max(p1.Y, point.Y)
 		for y := y1 + 1; y < y2; y++ {
-			d.board[y][point.X] |= 0x02
+			p := Point{point.X, y}
+			d.board.Set(p, d.board.At(p)|0x02)
 		}
 	}
 
+	d.setPolygon(d.poly)
+
 	for i, point := range d.input {
-		d.p1 = Point{point[0], point[1]}
+		d.p1 = point
 		for j := i + 1; j < len(d.input); j++ {
 			// find the max area between any two points
-			d.p2 = Point{d.input[j][0], d.input[j][1]}
-			area := area(d.p1, d.p2)
+			d.p2 = d.input[j]
+			area := NewRect(d.p1, d.p2).Area()
 			d.solution1 = max(d.solution1, area)
 			d.validRectangle = nil
 			if area > d.solution2 && d.validateRectangle(d.p1, d.p2) {
@@ -119,6 +117,7 @@ func (d *Day9) Run(updates chan<- DayUpdate) error {
 				updates <- DayUpdate{
 					View:     d.view(),
 					Solution: d.viewSolution(),
+					Answer:   d.answer(),
 					Done:     false,
 				}
 			}
@@ -130,6 +129,7 @@ func (d *Day9) Run(updates chan<- DayUpdate) error {
 	updates <- DayUpdate{
 		View:     d.view(),
 		Solution: d.viewSolution(),
+		Answer:   d.answer(),
 		Done:     true,
 	}
 	return nil
@@ -146,6 +146,29 @@ func (d *Day9) Run(updates chan<- DayUpdate) error {
 // .........#.#.. (9,7) (11,7)
 // ..............
 func (d *Day9) validateRectangle(p1 Point, p2 Point) bool {
+	var result bool
+	if d.validateByRows {
+		result = d.validateRectangleByRows(p1, p2)
+	} else {
+		result = d.mask.contains(p1, p2)
+	}
+	d.validRectangle = &result
+	return result
+}
+
+// setPolygon sets the polygon and precomputes the structures used to validate rectangles against it
+func (d *Day9) setPolygon(poly []Point) {
+	d.poly = poly
+	d.polygon = make(geometry.Polygon, len(poly))
+	for i, p := range poly {
+		d.polygon[i] = geometry.Point(p)
+	}
+	d.mask = newPolygonMask(poly)
+}
+
+// validateRectangleByRows is the original part 2 check. It scans every row of the rectangle
+// against the polygon, which is too slow for the real input but is a simple cross-check for the mask.
+func (d *Day9) validateRectangleByRows(p1 Point, p2 Point) bool {
 	// find which coord is up/left
 	y1 := min(p1.Y, p2.Y)
 	x1 := min(p1.X, p2.X)
@@ -154,17 +177,134 @@ func (d *Day9) validateRectangle(p1 Point, p2 Point) bool {
 	y2 := max(p1.Y, p2.Y)
 	x2 := max(p1.X, p2.X)
 
-	result := true
 	for y := y1; y < y2; y++ {
-		if !rowInside(d.poly, y, x1, x2) {
-			result := false
-			d.validRectangle = &result
-			return result
+		if !d.polygon.RowInside(y, x1, x2) {
+			return false
 		}
 	}
+	return true
+}
 
-	d.validRectangle = &result
-	return result
+// polygonMask is an inside/outside map of an axis aligned polygon, compressed down to the polygon's
+// distinct x and y values. Compressed cell 2i is the line at xs[i], cell 2i+1 is the gap between xs[i]
+// and xs[i+1]. Everything in a cell is either all inside or all outside, so a rectangle between two
+// corners is inside the polygon if none of its cells are outside, which prefix sums answer in O(1).
+type polygonMask struct {
+	xIndex map[int]int
+	yIndex map[int]int
+
+	// outside[r][c] counts the outside cells above and left of compressed row r, column c
+	outside [][]int
+}
+
+func newPolygonMask(poly []Point) *polygonMask {
+	xs, xIndex := compressCoordinates(poly, func(p Point) int { return p.X })
+	ys, yIndex := compressCoordinates(poly, func(p Point) int { return p.Y })
+
+	// for each band between ys[j] and ys[j+1], the x intervals inside the polygon
+	bands := make([][][2]int, len(ys)-1)
+	for j := range bands {
+		var crossings []int
+		for i := range poly {
+			a, b := poly[i], poly[(i+1)%len(poly)]
+			if a.X != b.X {
+				continue
+			}
+			if min(a.Y, b.Y) <= ys[j] && ys[j+1] <= max(a.Y, b.Y) {
+				crossings = append(crossings, a.X)
+			}
+		}
+		slices.Sort(crossings)
+		for i := 0; i+1 < len(crossings); i += 2 {
+			bands[j] = append(bands[j], [2]int{crossings[i], crossings[i+1]})
+		}
+	}
+
+	// horizontal edges sit on the lines between bands
+	edges := make([][][2]int, len(ys))
+	for i := range poly {
+		a, b := poly[i], poly[(i+1)%len(poly)]
+		if a.Y != b.Y {
+			continue
+		}
+		edges[yIndex[a.Y]] = append(edges[yIndex[a.Y]], [2]int{min(a.X, b.X), max(a.X, b.X)})
+	}
+
+	rows, cols := 2*len(ys)-1, 2*len(xs)-1
+	m := &polygonMask{
+		xIndex:  xIndex,
+		yIndex:  yIndex,
+		outside: MakeBoard[int](cols+1, rows+1),
+	}
+
+	for r := range rows {
+		// a line is inside wherever the band on either side of it is, plus any edges on it
+		var intervals [][2]int
+		if r%2 == 1 {
+			intervals = bands[r/2]
+		} else {
+			j := r / 2
+			intervals = append(intervals, edges[j]...)
+			if j > 0 {
+				intervals = append(intervals, bands[j-1]...)
+			}
+			if j < len(bands) {
+				intervals = append(intervals, bands[j]...)
+			}
+		}
+
+		// mark the intervals on the compressed columns, then sweep them into prefix sums
+		diff := make([]int, cols+1)
+		for _, in := range intervals {
+			diff[2*xIndex[in[0]]]++
+			diff[2*xIndex[in[1]]+1]--
+		}
+		inside := 0
+		for c := range cols {
+			inside += diff[c]
+			out := 0
+			if inside == 0 {
+				out = 1
+			}
+			m.outside[r+1][c+1] = out + m.outside[r][c+1] + m.outside[r+1][c] - m.outside[r][c]
+		}
+	}
+
+	return m
+}
+
+// compressCoordinates returns the sorted distinct values of coord and each value's index
+func compressCoordinates(poly []Point, coord func(p Point) int) ([]int, map[int]int) {
+	values := make([]int, len(poly))
+	for i, p := range poly {
+		values[i] = coord(p)
+	}
+	slices.Sort(values)
+	values = slices.Compact(values)
+
+	index := make(map[int]int, len(values))
+	for i, v := range values {
+		index[v] = i
+	}
+	return values, index
+}
+
+// contains is true if the rectangle with corners p1 and p2 is entirely inside the polygon.
+// The corners must be polygon vertices.
+func (m *polygonMask) contains(p1, p2 Point) bool {
+	x1, ok1 := m.xIndex[min(p1.X, p2.X)]
+	x2, ok2 := m.xIndex[max(p1.X, p2.X)]
+	y1, ok3 := m.yIndex[min(p1.Y, p2.Y)]
+	y2, ok4 := m.yIndex[max(p1.Y, p2.Y)]
+	if !ok1 || !ok2 || !ok3 || !ok4 {
+		return false
+	}
+
+	// convert to compressed cells, the prefix sums are offset by one
+	c1, c2 := 2*x1, 2*x2+1
+	r1, r2 := 2*y1, 2*y2+1
+	outside := m.outside[r2][c2] - m.outside[r1][c2] - m.outside[r2][c1] + m.outside[r1][c1]
+	return outside == 0
 }
 
 func (d *Day9) view() string {
@@ -172,44 +312,55 @@ func (d *Day9) view() string {
 		return ""
 	}
 
-	var sb strings.Builder
-	for y := 0; y < len(d.board); y++ {
-		for x := 0; x < len(d.board[y]); x++ {
-			switch d.board[y][x] {
-			case 0:
-				sb.WriteRune('.')
-			case 1, 3:
-				if d.p1 == (Point{x, y}) || d.p2 == (Point{x, y}) {
-					sb.WriteString(renderedHighlightedRedSquare)
-				} else {
-					sb.WriteString(renderedRedSquare)
-				}
-			case 2:
-				sb.WriteString(renderedGreenSquare)
-			}
-		}
-		sb.WriteRune('\n')
+	var board string
+	if d.board.Width() > day9MaxViewWidth || d.board.Height() > day9MaxViewHeight {
+		// real inputs are tens of thousands of cells across, scale them down to the same number of
+		// pixels, packed into braille characters
+		board = d.Theme.Braille(d.board.Pixels(day9MaxViewWidth/2, day9MaxViewHeight/4, func(p Point, v byte) bool { return v != 0 }))
+	} else {
+		board = d.renderBoard()
 	}
 
 	validity := ""
 	if d.validRectangle != nil && !*d.validRectangle {
-		validity = incorrectResultStyle.Render("invalid")
+		validity = d.Theme.Incorrect.Render("invalid")
 	} else if d.validRectangle != nil && *d.validRectangle {
-		validity = correctResultStyle.Render("valid")
+		validity = d.Theme.Correct.Render("valid")
 	}
 
 	return fmt.Sprintf("\n%s\np1: %s, p2: %s, area: %d, %s",
-		sb.String(),
-		data1Style.Render(d.p1.String()),
-		data2Style.Render(d.p2.String()),
-		area(d.p1, d.p2),
+		board,
+		d.Theme.Data1.Render(d.p1.String()),
+		d.Theme.Data2.Render(d.p2.String()),
+		NewRect(d.p1, d.p2).Area(),
 		validity,
 	)
 }
 
+// renderBoard draws the board one character per cell
+func (d *Day9) renderBoard() string {
+	return d.board.Render(func(p Point, v byte) string {
+		switch v {
+		case 1, 3:
+			if d.p1 == p || d.p2 == p {
+				return d.renderedHighlightedRedSquare
+			}
+			return d.renderedRedSquare
+		case 2:
+			return d.renderedGreenSquare
+		default:
+			return "."
+		}
+	})
+}
+
 func (d *Day9) viewSolution() string {
 	return fmt.Sprintf("solution1: %s solution2: %s",
-		solutionStyle.Render(strconv.Itoa(d.solution1)),
-		solutionStyle.Render(strconv.Itoa(d.solution2)),
+		d.Theme.Solution.Render(strconv.Itoa(d.solution1)),
+		d.Theme.Solution.Render(strconv.Itoa(d.solution2)),
 	)
 }
+
+func (d *Day9) answer() Answer {
+	return Answer{Part1: int64(d.solution1), Part2: int64(d.solution2)}
+}
@@ -0,0 +1,115 @@
+package advent
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sirgwain/advent-of-code-2025/advent/geometry"
+)
+
+// the example from the puzzle
+var day9Example = []Point{{7, 1}, {11, 1}, {11, 7}, {9, 7}, {9, 5}, {2, 5}, {2, 3}, {7, 3}}
+
+func TestDay9_polygonMask(t *testing.T) {
+	tests := []struct {
+		name string
+		poly []Point
+	}{
+		{name: "example", poly: day9Example},
+		{name: "square", poly: []Point{{0, 0}, {10, 0}, {10, 10}, {0, 10}}},
+		// a U shape, rectangles across the gap at the top are outside
+		{name: "u", poly: []Point{{0, 0}, {3, 0}, {3, 6}, {6, 6}, {6, 0}, {9, 0}, {9, 9}, {0, 9}}},
+		// a plus sign, with corners that only touch on a single line
+		{name: "plus", poly: []Point{
+			{4, 0}, {8, 0}, {8, 4}, {12, 4}, {12, 8}, {8, 8},
+			{8, 12}, {4, 12}, {4, 8}, {0, 8}, {0, 4}, {4, 4},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := Day9{}
+			d.setPolygon(tt.poly)
+			for i, p1 := range tt.poly {
+				for _, p2 := range tt.poly[i+1:] {
+					got := d.validateRectangle(p1, p2)
+					if want := d.polygon.ContainsRect(geometry.Point(p1), geometry.Point(p2)); got != want {
+						t.Errorf("validateRectangle(%v, %v) = %v, polygon.ContainsRect() = %v", p1, p2, got, want)
+					}
+
+					if p1.Y == p2.Y {
+						// the row scan never checks flat rectangles, see below
+						continue
+					}
+					if want := d.validateRectangleByRows(p1, p2); got != want {
+						t.Errorf("validateRectangle(%v, %v) = %v, want %v", p1, p2, got, want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestDay9_polygonMaskFlat(t *testing.T) {
+	poly := []Point{{0, 0}, {3, 0}, {3, 6}, {6, 6}, {6, 0}, {9, 0}, {9, 9}, {0, 9}}
+	mask := newPolygonMask(poly)
+	tests := []struct {
+		name   string
+		p1, p2 Point
+		want   bool
+	}{
+		{name: "along an edge", p1: Point{0, 0}, p2: Point{3, 0}, want: true},
+		{name: "across the gap", p1: Point{0, 0}, p2: Point{6, 0}, want: false},
+		{name: "across the bottom", p1: Point{0, 9}, p2: Point{9, 9}, want: true},
+		{name: "down a side", p1: Point{3, 0}, p2: Point{3, 6}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mask.contains(tt.p1, tt.p2); got != tt.want {
+				t.Errorf("polygonMask.contains() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDay9_Run(t *testing.T) {
+	d := Day9{}
+	if err := d.Init(strings.NewReader("7,1\n11,1\n11,7\n9,7\n9,5\n2,5\n2,3\n7,3\n"), NewRun(WithQuiet(true))); err != nil {
+		t.Fatalf("Day9.Init() error = %v", err)
+	}
+	updates := make(chan DayUpdate, 1)
+	if err := d.Run(updates); err != nil {
+		t.Fatalf("Day9.Run() error = %v", err)
+	}
+	if got, want := (<-updates).Answer, (Answer{Part1: 50, Part2: 24}); got != want {
+		t.Errorf("Day9.Run() = %v, want %v", got, want)
+	}
+}
+
+func BenchmarkDay9Part2(b *testing.B) {
+	d := Day9{}
+	if err := InitFile(&d, "../inputs/day9.txt", &Options{Quiet: true}); err != nil {
+		b.Fatalf("failed to load input %v", err)
+	}
+	d.setPolygon(d.input)
+
+	b.Run("mask", func(b *testing.B) {
+		for b.Loop() {
+			d.mask = newPolygonMask(d.poly)
+			for i, p1 := range d.poly {
+				for _, p2 := range d.poly[i+1:] {
+					d.validateRectangle(p1, p2)
+				}
+			}
+		}
+	})
+
+	b.Run("rows", func(b *testing.B) {
+		for b.Loop() {
+			for i, p1 := range d.poly {
+				for _, p2 := range d.poly[i+1:] {
+					d.validateRectangleByRows(p1, p2)
+				}
+			}
+		}
+	})
+}
@@ -4,6 +4,7 @@ package advent
 
 import (
 	"fmt"
+	"io"
 	"strconv"
 )
 
@@ -17,9 +18,11 @@ func (d *DayN) Day() int {
 	return 0
 }
 
-// Init loads in the input from the file and initializes the Day
-func (d *DayN) Init(filename string, options *Options) (err error) {
+// Init loads in the input and initializes the Day
+func (d *DayN) Init(r io.Reader, options *Options) (err error) {
 	d.Options = options
+	// errors from readInput and parse formats are reported as a ParseError
+	defer func() { err = asParseError(d.Day(), err) }()
 	return nil
 }
 
@@ -28,6 +31,7 @@ func (d *DayN) Run(updates chan<- DayUpdate) error {
 	updates <- DayUpdate{
 		View:     d.view(),
 		Solution: d.viewSolution(),
+		Answer:   d.answer(),
 		Done:     true,
 	}
 	return nil
@@ -42,7 +46,11 @@ func (d *DayN) view() string {
 
 func (d *DayN) viewSolution() string {
 	return fmt.Sprintf("solution1: %s solution2: %s",
-		solutionStyle.Render(strconv.Itoa(d.solution1)),
-		solutionStyle.Render(strconv.Itoa(d.solution2)),
+		d.Theme.Solution.Render(strconv.Itoa(d.solution1)),
+		d.Theme.Solution.Render(strconv.Itoa(d.solution2)),
 	)
 }
+
+func (d *DayN) answer() Answer {
+	return Answer{Part1: int64(d.solution1), Part2: int64(d.solution2)}
+}
@@ -0,0 +1,36 @@
+package advent
+
+import "fmt"
+
+// NewDay returns a new, uninitialized, Day by number
+func NewDay(day int) (Day, error) {
+	switch day {
+	case 0:
+		return &DayN{}, nil
+	case 1:
+		return &Day1{}, nil
+	case 2:
+		return &Day2{}, nil
+	case 3:
+		return &Day3{}, nil
+	case 4:
+		return &Day4{}, nil
+	case 5:
+		return &Day5{}, nil
+	case 6:
+		return &Day6{}, nil
+	case 7:
+		return &Day7{}, nil
+	case 8:
+		return &Day8{}, nil
+	case 9:
+		return &Day9{}, nil
+	case 10:
+		return &Day10{}, nil
+	case 11:
+		return &Day11{}, nil
+	case 12:
+		return &Day12{}, nil
+	}
+	return nil, fmt.Errorf("day %d not found", day)
+}
@@ -166,13 +166,13 @@ func (d Direction) getChar() rune {
 	return ' '
 }
 
+// MinTurns is the fewest 90 degree turns, left or right, to face other
 func (d Direction) MinTurns(other Direction) int {
 	dIndex := slices.Index(CardinalDirections, d)
 	otherIndex := slices.Index(CardinalDirections, other)
-	if otherIndex > dIndex {
-		return min(2, otherIndex-dIndex)
-	}
-	return min(2, dIndex-otherIndex)
+	turns := Abs(otherIndex - dIndex)
+	// up -> left is one turn left, not three turns right
+	return min(turns, len(CardinalDirections)-turns)
 }
 
 func (d Direction) String() string {
@@ -12,6 +12,12 @@ func Test_direction_minTurns(t *testing.T) {
 		{"up->right", DirectionUp, DirectionRight, 1},
 		{"up->left", DirectionUp, DirectionLeft, 1},
 		{"up->down", DirectionUp, DirectionDown, 2},
+		{"up->up", DirectionUp, DirectionUp, 0},
+		// turning through up, the ends of CardinalDirections
+		{"left->up", DirectionLeft, DirectionUp, 1},
+		{"left->right", DirectionLeft, DirectionRight, 2},
+		{"right->left", DirectionRight, DirectionLeft, 2},
+		{"down->left", DirectionDown, DirectionLeft, 1},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
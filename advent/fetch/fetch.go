@@ -0,0 +1,228 @@
+// Package fetch downloads puzzle inputs and keeps them in a local cache, so each input is only downloaded once
+package fetch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultBaseURL = "https://adventofcode.com"
+	DefaultYear    = 2025
+	DefaultDir     = "inputs"
+
+	// DefaultRateLimit is the least time between requests to the server
+	DefaultRateLimit = 5 * time.Second
+
+	// the site asks automated tools to say who they are
+	userAgent = "github.com/sirgwain/advent-of-code-2025 fetch"
+
+	// stampFile is touched on every request, so the rate limit holds across runs
+	stampFile = ".last-fetch"
+
+	// inputs are a few kilobytes, anything this big is a mistake
+	maxInputSize = 4 << 20
+)
+
+var (
+	ErrNoSession    = errors.New("no session token")
+	ErrUnauthorized = errors.New("session token was rejected, it may have expired")
+	ErrNotFound     = errors.New("input isn't available, the puzzle may not be unlocked yet")
+)
+
+// Client downloads inputs into a cache dir
+type Client struct {
+	session   string
+	baseURL   string
+	year      int
+	dir       string
+	rateLimit time.Duration
+	http      *http.Client
+}
+
+// Option configures a Client
+type Option func(c *Client)
+
+// WithBaseURL sets the server to download from, i.e. an httptest server in tests
+func WithBaseURL(url string) Option {
+	return func(c *Client) {
+		c.baseURL = strings.TrimSuffix(url, "/")
+	}
+}
+
+// WithYear sets the year to download inputs for
+func WithYear(year int) Option {
+	return func(c *Client) {
+		c.year = year
+	}
+}
+
+// WithDir sets the cache dir inputs are saved to
+func WithDir(dir string) Option {
+	return func(c *Client) {
+		c.dir = dir
+	}
+}
+
+// WithRateLimit sets the least time between requests
+func WithRateLimit(d time.Duration) Option {
+	return func(c *Client) {
+		c.rateLimit = d
+	}
+}
+
+// WithHTTPClient sets the http client requests are made with
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *Client) {
+		c.http = client
+	}
+}
+
+// New makes a client that downloads with a session token, the session cookie from a logged in browser
+func New(session string, opts ...Option) *Client {
+	c := &Client{
+		session:   session,
+		baseURL:   DefaultBaseURL,
+		year:      DefaultYear,
+		dir:       DefaultDir,
+		rateLimit: DefaultRateLimit,
+		http:      &http.Client{Timeout: 30 * time.Second},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Path is where the input for a day is cached, i.e. inputs/day5.txt
+func (c *Client) Path(day int) string {
+	return filepath.Join(c.dir, fmt.Sprintf("day%d.txt", day))
+}
+
+// Fetch returns the path to the input for a day, downloading it first if it isn't cached. cached is true if it was
+// already there. A cached input is never downloaded again, delete the file to get a fresh copy.
+func (c *Client) Fetch(ctx context.Context, day int) (path string, cached bool, err error) {
+	if day < 1 || day > 25 {
+		return "", false, fmt.Errorf("day %d is out of range, days are 1 to 25", day)
+	}
+	path = c.Path(day)
+	if info, err := os.Stat(path); err == nil && info.Size() > 0 {
+		return path, true, nil
+	}
+	if c.session == "" {
+		return "", false, ErrNoSession
+	}
+
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return "", false, fmt.Errorf("failed to create input dir %s %w", c.dir, err)
+	}
+	if err := c.wait(ctx); err != nil {
+		return "", false, err
+	}
+	// without the stamp the next run wouldn't know to wait, so don't make a request that can't be recorded
+	if err := c.stamp(); err != nil {
+		return "", false, fmt.Errorf("failed to record the time of the request %w", err)
+	}
+	input, err := c.download(ctx, day)
+	if err != nil {
+		return "", false, fmt.Errorf("day %d: %w", day, err)
+	}
+	if err := writeFile(path, input); err != nil {
+		return "", false, err
+	}
+	return path, false, nil
+}
+
+// wait sleeps until the rate limit allows another request
+func (c *Client) wait(ctx context.Context) error {
+	info, err := os.Stat(filepath.Join(c.dir, stampFile))
+	if err != nil {
+		// never fetched before
+		return nil
+	}
+	delay := c.rateLimit - time.Since(info.ModTime())
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// stamp records that a request is being made, failed requests count against the rate limit too
+func (c *Client) stamp() error {
+	return os.WriteFile(filepath.Join(c.dir, stampFile), nil, 0o644)
+}
+
+func (c *Client) download(ctx context.Context, day int) ([]byte, error) {
+	url := fmt.Sprintf("%s/%d/day/%d/input", c.baseURL, c.year, day)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.AddCookie(&http.Cookie{Name: "session", Value: c.session})
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden:
+		return nil, ErrUnauthorized
+	case http.StatusNotFound:
+		return nil, ErrNotFound
+	default:
+		return nil, fmt.Errorf("unexpected response from %s: %s", url, resp.Status)
+	}
+
+	input, err := io.ReadAll(io.LimitReader(resp.Body, maxInputSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read input %w", err)
+	}
+	switch {
+	case len(input) == 0:
+		return nil, fmt.Errorf("input is empty")
+	case len(input) > maxInputSize:
+		return nil, fmt.Errorf("input is more than %d bytes", maxInputSize)
+	}
+	return input, nil
+}
+
+// writeFile writes to a temp file and renames it, so an interrupted download never leaves a partial input that
+// would be mistaken for a cached one
+func writeFile(path string, content []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".fetch-*")
+	if err != nil {
+		return fmt.Errorf("failed to save input %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to save input %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to save input %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to save input %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package fetch_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirgwain/advent-of-code-2025/advent/fetch"
+)
+
+// server is a stand in for the puzzle site, it serves inputs for days 1 to 3 to the "good" session
+type server struct {
+	*httptest.Server
+	mu       sync.Mutex
+	requests []time.Time
+}
+
+func newServer(t *testing.T) *server {
+	s := &server{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /2025/day/{day}/input", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests = append(s.requests, time.Now())
+		s.mu.Unlock()
+
+		if r.UserAgent() == "" || r.UserAgent() == "Go-http-client/1.1" {
+			http.Error(w, "say who you are", http.StatusForbidden)
+			return
+		}
+		if cookie, err := r.Cookie("session"); err != nil || cookie.Value != "good" {
+			http.Error(w, "Puzzle inputs differ by user. Please log in to get your puzzle input.", http.StatusBadRequest)
+			return
+		}
+		switch day := r.PathValue("day"); day {
+		case "1", "2", "3":
+			w.Write([]byte("input for day " + day + "\n"))
+		case "4":
+			w.WriteHeader(http.StatusOK)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	s.Server = httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *server) count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.requests)
+}
+
+func TestClient_Fetch(t *testing.T) {
+	s := newServer(t)
+	dir := filepath.Join(t.TempDir(), "inputs")
+	client := fetch.New("good", fetch.WithBaseURL(s.URL), fetch.WithDir(dir), fetch.WithRateLimit(0))
+
+	path, cached, err := client.Fetch(context.Background(), 1)
+	if err != nil || cached || path != filepath.Join(dir, "day1.txt") {
+		t.Fatalf("Fetch(1) = %s, %v, %v, want a download to %s", path, cached, err, filepath.Join(dir, "day1.txt"))
+	}
+	if content, err := os.ReadFile(path); err != nil || string(content) != "input for day 1\n" {
+		t.Errorf("Fetch(1) saved %q, %v", content, err)
+	}
+
+	// the second time comes from the cache, without a request or even a session
+	offline := fetch.New("", fetch.WithBaseURL(s.URL), fetch.WithDir(dir))
+	if path, cached, err := offline.Fetch(context.Background(), 1); err != nil || !cached || path != filepath.Join(dir, "day1.txt") {
+		t.Errorf("Fetch(1) = %s, %v, %v, want it cached", path, cached, err)
+	}
+	if s.count() != 1 {
+		t.Errorf("server had %d requests, want 1", s.count())
+	}
+}
+
+func TestClient_FetchErrors(t *testing.T) {
+	s := newServer(t)
+	tests := []struct {
+		name    string
+		session string
+		day     int
+		wantErr error
+	}{
+		{"no session", "", 1, fetch.ErrNoSession},
+		{"bad session", "bad", 1, fetch.ErrUnauthorized},
+		{"locked", "good", 12, fetch.ErrNotFound},
+		{"empty", "good", 4, nil},
+		{"out of range", "good", 26, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			client := fetch.New(tt.session, fetch.WithBaseURL(s.URL), fetch.WithDir(dir), fetch.WithRateLimit(0))
+			_, _, err := client.Fetch(context.Background(), tt.day)
+			if err == nil || (tt.wantErr != nil && !errors.Is(err, tt.wantErr)) {
+				t.Errorf("Fetch(%d) error = %v, want %v", tt.day, err, tt.wantErr)
+			}
+			// nothing is cached when a download fails
+			if _, err := os.Stat(client.Path(tt.day)); !errors.Is(err, os.ErrNotExist) {
+				t.Errorf("Fetch(%d) left a file at %s", tt.day, client.Path(tt.day))
+			}
+		})
+	}
+}
+
+func TestClient_FetchStampError(t *testing.T) {
+	s := newServer(t)
+	dir := t.TempDir()
+	// a directory where the stamp goes can't be written over
+	if err := os.Mkdir(filepath.Join(dir, ".last-fetch"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	client := fetch.New("good", fetch.WithBaseURL(s.URL), fetch.WithDir(dir), fetch.WithRateLimit(0))
+	if _, _, err := client.Fetch(context.Background(), 1); err == nil {
+		t.Errorf("Fetch(1) succeeded without recording the request")
+	}
+	if s.count() != 0 {
+		t.Errorf("server had %d requests, want none when the rate limit can't be kept", s.count())
+	}
+}
+
+func TestClient_RateLimit(t *testing.T) {
+	s := newServer(t)
+	dir := t.TempDir()
+	const limit = 200 * time.Millisecond
+
+	// the limit holds across clients, like separate runs of the cli
+	for day := 1; day <= 3; day++ {
+		client := fetch.New("good", fetch.WithBaseURL(s.URL), fetch.WithDir(dir), fetch.WithRateLimit(limit))
+		if _, _, err := client.Fetch(context.Background(), day); err != nil {
+			t.Fatalf("Fetch(%d) error = %v", day, err)
+		}
+	}
+
+	s.mu.Lock()
+	requests := slices.Clone(s.requests)
+	s.mu.Unlock()
+	for i := 1; i < len(requests); i++ {
+		// file times can be a little coarse, so leave some slack
+		if gap := requests[i].Sub(requests[i-1]); gap < limit-20*time.Millisecond {
+			t.Errorf("request %d came %v after the last one, want at least %v", i+1, gap, limit)
+		}
+	}
+
+	// waiting gives up when the context does
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	client := fetch.New("good", fetch.WithBaseURL(s.URL), fetch.WithDir(dir), fetch.WithRateLimit(time.Hour))
+	if _, _, err := client.Fetch(ctx, 5); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Fetch(5) error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
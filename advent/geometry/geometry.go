@@ -0,0 +1,93 @@
+// Package geometry has exact integer geometry for points, segments and polygons
+package geometry
+
+import "fmt"
+
+// Point is a 2D point with integer coordinates. It has the same layout as advent.Point, so the two convert
+// directly, i.e. geometry.Point(p).
+type Point struct {
+	X int
+	Y int
+}
+
+func (p Point) String() string {
+	return fmt.Sprintf("%d, %d", p.X, p.Y)
+}
+
+func (p Point) add(o Point) Point {
+	return Point{p.X + o.X, p.Y + o.Y}
+}
+
+func (p Point) scale(k int) Point {
+	return Point{p.X * k, p.Y * k}
+}
+
+// Orientation returns 1 if a, b, c turn counter clockwise (with y up), -1 if they turn clockwise and 0 if they are collinear
+func Orientation(a, b, c Point) int {
+	cross := (b.X-a.X)*(c.Y-a.Y) - (b.Y-a.Y)*(c.X-a.X)
+	switch {
+	case cross > 0:
+		return 1
+	case cross < 0:
+		return -1
+	}
+	return 0
+}
+
+// Segment is the closed line segment from A to B
+type Segment struct {
+	A Point
+	B Point
+}
+
+// Contains is true if p is on the segment, including its ends
+func (s Segment) Contains(p Point) bool {
+	return Orientation(s.A, s.B, p) == 0 &&
+		p.X >= min(s.A.X, s.B.X) && p.X <= max(s.A.X, s.B.X) &&
+		p.Y >= min(s.A.Y, s.B.Y) && p.Y <= max(s.A.Y, s.B.Y)
+}
+
+// Intersects is true if the segments share at least one point, including touching ends and collinear overlaps
+func (s Segment) Intersects(o Segment) bool {
+	d1 := Orientation(o.A, o.B, s.A)
+	d2 := Orientation(o.A, o.B, s.B)
+	d3 := Orientation(s.A, s.B, o.A)
+	d4 := Orientation(s.A, s.B, o.B)
+	if d1*d2 < 0 && d3*d4 < 0 {
+		return true
+	}
+	return o.Contains(s.A) || o.Contains(s.B) || s.Contains(o.A) || s.Contains(o.B)
+}
+
+// Crosses is true if the segments cross at a single point inside both of them, like an X.
+// Touching ends, T junctions and collinear overlaps don't cross.
+func (s Segment) Crosses(o Segment) bool {
+	return Orientation(o.A, o.B, s.A)*Orientation(o.A, o.B, s.B) < 0 &&
+		Orientation(s.A, s.B, o.A)*Orientation(s.A, s.B, o.B) < 0
+}
+
+// crossesOpenBox is true if any point of the segment is strictly inside the box from lo to hi.
+// The segment and the box are both convex, so they miss each other only if one of the box's axes or the
+// segment's normal separates them.
+func (s Segment) crossesOpenBox(lo, hi Point) bool {
+	if s.A == s.B {
+		return s.A.X > lo.X && s.A.X < hi.X && s.A.Y > lo.Y && s.A.Y < hi.Y
+	}
+	if max(s.A.X, s.B.X) <= lo.X || min(s.A.X, s.B.X) >= hi.X ||
+		max(s.A.Y, s.B.Y) <= lo.Y || min(s.A.Y, s.B.Y) >= hi.Y {
+		return false
+	}
+
+	// the box is separated if all its corners are on one side of the segment's line
+	corners := [4]Point{lo, {hi.X, lo.Y}, hi, {lo.X, hi.Y}}
+	left, right := false, false
+	for _, c := range corners {
+		switch Orientation(s.A, s.B, c) {
+		case 1:
+			left = true
+		case -1:
+			right = true
+		}
+	}
+	return left && right
+}
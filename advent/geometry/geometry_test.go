@@ -0,0 +1,84 @@
+package geometry_test
+
+import (
+	"testing"
+
+	"github.com/sirgwain/advent-of-code-2025/advent/geometry"
+)
+
+type Point = geometry.Point
+type Segment = geometry.Segment
+
+func TestOrientation(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, b, c Point
+		want    int
+	}{
+		{name: "counter clockwise", a: Point{0, 0}, b: Point{4, 0}, c: Point{4, 4}, want: 1},
+		{name: "clockwise", a: Point{0, 0}, b: Point{4, 0}, c: Point{4, -4}, want: -1},
+		{name: "collinear", a: Point{0, 0}, b: Point{2, 2}, c: Point{5, 5}, want: 0},
+		{name: "collinear behind", a: Point{0, 0}, b: Point{2, 2}, c: Point{-1, -1}, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := geometry.Orientation(tt.a, tt.b, tt.c); got != tt.want {
+				t.Errorf("Orientation() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSegment_Contains(t *testing.T) {
+	s := Segment{Point{0, 0}, Point{6, 3}}
+	tests := []struct {
+		name string
+		p    Point
+		want bool
+	}{
+		{name: "start", p: Point{0, 0}, want: true},
+		{name: "end", p: Point{6, 3}, want: true},
+		{name: "middle", p: Point{4, 2}, want: true},
+		{name: "off the line", p: Point{4, 3}, want: false},
+		{name: "on the line past the end", p: Point{8, 4}, want: false},
+		{name: "on the line before the start", p: Point{-2, -1}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.Contains(tt.p); got != tt.want {
+				t.Errorf("Segment.Contains() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSegment_Intersects(t *testing.T) {
+	tests := []struct {
+		name           string
+		s, o           Segment
+		wantIntersects bool
+		wantCrosses    bool
+	}{
+		{name: "x", s: Segment{Point{0, 0}, Point{4, 4}}, o: Segment{Point{0, 4}, Point{4, 0}}, wantIntersects: true, wantCrosses: true},
+		{name: "plus", s: Segment{Point{2, 0}, Point{2, 4}}, o: Segment{Point{0, 2}, Point{4, 2}}, wantIntersects: true, wantCrosses: true},
+		{name: "t junction", s: Segment{Point{2, 0}, Point{2, 2}}, o: Segment{Point{0, 2}, Point{4, 2}}, wantIntersects: true, wantCrosses: false},
+		{name: "shared end", s: Segment{Point{0, 0}, Point{2, 2}}, o: Segment{Point{2, 2}, Point{4, 0}}, wantIntersects: true, wantCrosses: false},
+		{name: "collinear overlap", s: Segment{Point{0, 0}, Point{4, 0}}, o: Segment{Point{2, 0}, Point{6, 0}}, wantIntersects: true, wantCrosses: false},
+		{name: "collinear apart", s: Segment{Point{0, 0}, Point{2, 0}}, o: Segment{Point{3, 0}, Point{6, 0}}, wantIntersects: false, wantCrosses: false},
+		{name: "parallel", s: Segment{Point{0, 0}, Point{4, 0}}, o: Segment{Point{0, 1}, Point{4, 1}}, wantIntersects: false, wantCrosses: false},
+		{name: "would cross if longer", s: Segment{Point{0, 0}, Point{1, 1}}, o: Segment{Point{0, 4}, Point{4, 0}}, wantIntersects: false, wantCrosses: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// both directions should agree
+			for _, pair := range [][2]Segment{{tt.s, tt.o}, {tt.o, tt.s}} {
+				if got := pair[0].Intersects(pair[1]); got != tt.wantIntersects {
+					t.Errorf("Segment.Intersects(%v, %v) = %v, want %v", pair[0], pair[1], got, tt.wantIntersects)
+				}
+				if got := pair[0].Crosses(pair[1]); got != tt.wantCrosses {
+					t.Errorf("Segment.Crosses(%v, %v) = %v, want %v", pair[0], pair[1], got, tt.wantCrosses)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,237 @@
+package geometry
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Location is where a point is relative to a polygon
+type Location int
+
+const (
+	Outside Location = iota
+	Boundary
+	Inside
+)
+
+func (l Location) String() string {
+	switch l {
+	case Outside:
+		return "outside"
+	case Boundary:
+		return "boundary"
+	case Inside:
+		return "inside"
+	}
+	return ""
+}
+
+// Polygon is a simple polygon, its vertices in order. The last vertex connects back to the first.
+type Polygon []Point
+
+// Edges returns each edge of the polygon, from vertex i to vertex i+1
+func (poly Polygon) Edges() []Segment {
+	edges := make([]Segment, len(poly))
+	for i := range poly {
+		edges[i] = Segment{poly[i], poly[(i+1)%len(poly)]}
+	}
+	return edges
+}
+
+// IsRectilinear is true if every edge is horizontal or vertical
+func (poly Polygon) IsRectilinear() bool {
+	for _, e := range poly.Edges() {
+		if e.A.X != e.B.X && e.A.Y != e.B.Y {
+			return false
+		}
+	}
+	return true
+}
+
+// DoubledArea is twice the area of the polygon, from the shoelace formula. It's doubled so it's always
+// a whole number.
+func (poly Polygon) DoubledArea() int {
+	sum := 0
+	for _, e := range poly.Edges() {
+		sum += e.A.X*e.B.Y - e.B.X*e.A.Y
+	}
+	return abs(sum)
+}
+
+// Area is the area of the polygon
+func (poly Polygon) Area() float64 {
+	return float64(poly.DoubledArea()) / 2
+}
+
+// BoundaryPoints counts the integer points on the edges of the polygon
+func (poly Polygon) BoundaryPoints() int {
+	count := 0
+	for _, e := range poly.Edges() {
+		count += gcd(abs(e.B.X-e.A.X), abs(e.B.Y-e.A.Y))
+	}
+	return count
+}
+
+// InteriorPoints counts the integer points strictly inside the polygon, using Pick's theorem:
+// A = I + B/2 - 1
+func (poly Polygon) InteriorPoints() int {
+	return (poly.DoubledArea() - poly.BoundaryPoints() + 2) / 2
+}
+
+// LatticePoints counts the integer points inside or on the polygon. For a polygon drawn through the
+// centers of grid cells, this is the number of cells it covers.
+func (poly Polygon) LatticePoints() int {
+	return poly.InteriorPoints() + poly.BoundaryPoints()
+}
+
+// Locate finds whether p is inside, outside or on the boundary of the polygon
+func (poly Polygon) Locate(p Point) Location {
+	return poly.locate(p, 1)
+}
+
+// Contains is true if p is inside or on the boundary of the polygon
+func (poly Polygon) Contains(p Point) bool {
+	return poly.Locate(p) != Outside
+}
+
+// locate finds where q is relative to the polygon scaled up by scale. Scaling by 2 lets us
+// locate points halfway between integer points without fractions.
+func (poly Polygon) locate(q Point, scale int) Location {
+	inside := false
+	for _, e := range poly.Edges() {
+		a, b := e.A.scale(scale), e.B.scale(scale)
+		if (Segment{a, b}).Contains(q) {
+			return Boundary
+		}
+
+		// cast a ray to the right of q and count the edges it crosses
+		if (a.Y > q.Y) == (b.Y > q.Y) {
+			continue
+		}
+		// q is left of the edge where q.X < a.X + (q.Y-a.Y)*(b.X-a.X)/(b.Y-a.Y),
+		// multiplied out so there's no division
+		lhs := (q.X - a.X) * (b.Y - a.Y)
+		rhs := (q.Y - a.Y) * (b.X - a.X)
+		if b.Y < a.Y {
+			lhs, rhs = -lhs, -rhs
+		}
+		if lhs < rhs {
+			inside = !inside
+		}
+	}
+	if inside {
+		return Inside
+	}
+	return Outside
+}
+
+// ContainsRect is true if the axis aligned rectangle with opposite corners a and b, including its edges,
+// is entirely inside or on the boundary of the polygon. Flat rectangles and single points are allowed.
+func (poly Polygon) ContainsRect(a, b Point) bool {
+	lo := Point{min(a.X, b.X), min(a.Y, b.Y)}
+	hi := Point{max(a.X, b.X), max(a.Y, b.Y)}
+
+	if lo == hi {
+		return poly.Contains(lo)
+	}
+	if lo.X == hi.X || lo.Y == hi.Y {
+		return poly.containsSegment(Segment{lo, hi})
+	}
+
+	// if no edge passes through the inside of the rectangle, the whole inside is on the same side of
+	// the polygon as its center
+	for _, e := range poly.Edges() {
+		if e.crossesOpenBox(lo, hi) {
+			return false
+		}
+	}
+	return poly.locate(lo.add(hi), 2) == Inside
+}
+
+// containsSegment is true if every point of s is inside or on the boundary of the polygon
+func (poly Polygon) containsSegment(s Segment) bool {
+	// split s at every vertex on it. Each piece is then entirely inside, outside or on an edge, unless
+	// an edge crosses it, which means part of it is outside.
+	stops := []Point{s.A, s.B}
+	for _, e := range poly.Edges() {
+		if e.Crosses(s) {
+			return false
+		}
+		if s.Contains(e.A) {
+			stops = append(stops, e.A)
+		}
+	}
+	slices.SortFunc(stops, func(p, q Point) int {
+		return cmp.Or(cmp.Compare(p.X, q.X), cmp.Compare(p.Y, q.Y))
+	})
+	stops = slices.Compact(stops)
+
+	for i := 1; i < len(stops); i++ {
+		if poly.locate(stops[i-1].add(stops[i]), 2) == Outside {
+			return false
+		}
+	}
+	return true
+}
+
+// RowInside is true if the band between y and y+1 is inside a rectilinear polygon from x1 to x2.
+// Only vertical edges are considered, so it's only meaningful for rectilinear polygons.
+func (poly Polygon) RowInside(y, x1, x2 int) bool {
+	if x1 > x2 {
+		x1, x2 = x2, x1
+	}
+
+	var xs []int
+	for _, e := range poly.Edges() {
+		a, b := e.A, e.B
+
+		// Only vertical edges matter for a horizontal scan.
+		if a.X != b.X {
+			continue
+		}
+
+		// Normalize so a.Y <= b.Y
+		if a.Y > b.Y {
+			a, b = b, a
+		}
+
+		// We conceptually sample at y+0.5.
+		// That lies in the band [a.Y, b.Y) exactly when:
+		// a.Y <= y+0.5 < b.Y  <=>  a.Y <= y and y+1 <= b.Y
+		if !(a.Y <= y && y+1 <= b.Y) {
+			continue
+		}
+
+		xs = append(xs, a.X)
+	}
+
+	if len(xs) == 0 {
+		// This row never enters the polygon.
+		return false
+	}
+
+	slices.Sort(xs)
+
+	// Pairs of crossings are the runs inside the polygon, check if [x1, x2] fits in one of them.
+	// For axis-aligned / integer, treat boundary as inside.
+	for i := 0; i+1 < len(xs); i += 2 {
+		if x1 >= xs[i] && x2 <= xs[i+1] {
+			return true
+		}
+	}
+	return false
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
@@ -0,0 +1,213 @@
+package geometry_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/sirgwain/advent-of-code-2025/advent/geometry"
+)
+
+// the day 9 example
+var example = geometry.Polygon{{7, 1}, {11, 1}, {11, 7}, {9, 7}, {9, 5}, {2, 5}, {2, 3}, {7, 3}}
+
+// a U, open at the top between x=3 and x=6
+var u = geometry.Polygon{{0, 0}, {3, 0}, {3, 6}, {6, 6}, {6, 0}, {9, 0}, {9, 9}, {0, 9}}
+
+// a triangle with a slanted edge
+var triangle = geometry.Polygon{{0, 0}, {8, 0}, {0, 6}}
+
+func TestPolygon_Area(t *testing.T) {
+	tests := []struct {
+		name         string
+		poly         geometry.Polygon
+		wantDoubled  int
+		wantBoundary int
+		wantInterior int
+		wantLattice  int
+	}{
+		{name: "square", poly: geometry.Polygon{{0, 0}, {4, 0}, {4, 4}, {0, 4}}, wantDoubled: 32, wantBoundary: 16, wantInterior: 9, wantLattice: 25},
+		{name: "clockwise square", poly: geometry.Polygon{{0, 0}, {0, 4}, {4, 4}, {4, 0}}, wantDoubled: 32, wantBoundary: 16, wantInterior: 9, wantLattice: 25},
+		{name: "triangle", poly: triangle, wantDoubled: 48, wantBoundary: 16, wantInterior: 17, wantLattice: 33},
+		{name: "half unit triangle", poly: geometry.Polygon{{0, 0}, {1, 0}, {0, 1}}, wantDoubled: 1, wantBoundary: 3, wantInterior: 0, wantLattice: 3},
+		{name: "example", poly: example, wantDoubled: 60, wantBoundary: 30, wantInterior: 16, wantLattice: 46},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.poly.DoubledArea(); got != tt.wantDoubled {
+				t.Errorf("Polygon.DoubledArea() = %v, want %v", got, tt.wantDoubled)
+			}
+			if got := tt.poly.Area(); got != float64(tt.wantDoubled)/2 {
+				t.Errorf("Polygon.Area() = %v, want %v", got, float64(tt.wantDoubled)/2)
+			}
+			if got := tt.poly.BoundaryPoints(); got != tt.wantBoundary {
+				t.Errorf("Polygon.BoundaryPoints() = %v, want %v", got, tt.wantBoundary)
+			}
+			if got := tt.poly.InteriorPoints(); got != tt.wantInterior {
+				t.Errorf("Polygon.InteriorPoints() = %v, want %v", got, tt.wantInterior)
+			}
+			if got := tt.poly.LatticePoints(); got != tt.wantLattice {
+				t.Errorf("Polygon.LatticePoints() = %v, want %v", got, tt.wantLattice)
+			}
+		})
+	}
+}
+
+// TestPolygon_LatticePoints checks Pick's theorem against counting every point with Locate
+func TestPolygon_LatticePoints(t *testing.T) {
+	for _, poly := range []geometry.Polygon{example, u, triangle} {
+		interior, boundary := 0, 0
+		for y := -1; y <= 10; y++ {
+			for x := -1; x <= 12; x++ {
+				switch poly.Locate(Point{x, y}) {
+				case geometry.Inside:
+					interior++
+				case geometry.Boundary:
+					boundary++
+				}
+			}
+		}
+		if interior != poly.InteriorPoints() || boundary != poly.BoundaryPoints() {
+			t.Errorf("%v: counted %d interior, %d boundary, Pick's theorem gave %d, %d",
+				poly, interior, boundary, poly.InteriorPoints(), poly.BoundaryPoints())
+		}
+	}
+}
+
+func TestPolygon_Locate(t *testing.T) {
+	tests := []struct {
+		name string
+		poly geometry.Polygon
+		p    Point
+		want geometry.Location
+	}{
+		{name: "vertex", poly: example, p: Point{7, 1}, want: geometry.Boundary},
+		{name: "edge", poly: example, p: Point{9, 1}, want: geometry.Boundary},
+		{name: "inside", poly: example, p: Point{8, 4}, want: geometry.Inside},
+		{name: "outside", poly: example, p: Point{3, 2}, want: geometry.Outside},
+		{name: "level with a horizontal edge", poly: example, p: Point{1, 3}, want: geometry.Outside},
+		{name: "level with a vertex", poly: example, p: Point{12, 5}, want: geometry.Outside},
+		{name: "in the gap of the u", poly: u, p: Point{4, 3}, want: geometry.Outside},
+		{name: "across the gap of the u", poly: u, p: Point{4, 0}, want: geometry.Outside},
+		{name: "below the gap of the u", poly: u, p: Point{4, 7}, want: geometry.Inside},
+		{name: "on the slanted edge", poly: triangle, p: Point{4, 3}, want: geometry.Boundary},
+		{name: "just inside the slanted edge", poly: triangle, p: Point{3, 3}, want: geometry.Inside},
+		{name: "just outside the slanted edge", poly: triangle, p: Point{5, 3}, want: geometry.Outside},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.poly.Locate(tt.p); got != tt.want {
+				t.Errorf("Polygon.Locate() = %v, want %v", got, tt.want)
+			}
+			if got := tt.poly.Contains(tt.p); got != (tt.want != geometry.Outside) {
+				t.Errorf("Polygon.Contains() = %v, want %v", got, tt.want != geometry.Outside)
+			}
+		})
+	}
+}
+
+func TestPolygon_ContainsRect(t *testing.T) {
+	tests := []struct {
+		name string
+		poly geometry.Polygon
+		a, b Point
+		want bool
+	}{
+		{name: "example answer", poly: example, a: Point{9, 5}, b: Point{2, 3}, want: true},
+		{name: "example too big", poly: example, a: Point{2, 5}, b: Point{11, 1}, want: false},
+		{name: "example corners inside, edge outside", poly: example, a: Point{7, 3}, b: Point{11, 7}, want: false},
+		{name: "whole u", poly: u, a: Point{0, 0}, b: Point{9, 9}, want: false},
+		{name: "bottom of the u", poly: u, a: Point{0, 6}, b: Point{9, 9}, want: true},
+		{name: "left arm of the u", poly: u, a: Point{0, 0}, b: Point{3, 9}, want: true},
+		// the corners and edges are all on the polygon, but the inside is the gap
+		{name: "gap of the u", poly: u, a: Point{3, 0}, b: Point{6, 6}, want: false},
+		{name: "flat along the bottom", poly: u, a: Point{0, 9}, b: Point{9, 9}, want: true},
+		{name: "flat across the gap", poly: u, a: Point{0, 0}, b: Point{9, 0}, want: false},
+		{name: "flat along the gap floor", poly: u, a: Point{3, 6}, b: Point{6, 6}, want: true},
+		{name: "flat through the middle", poly: u, a: Point{0, 7}, b: Point{9, 7}, want: true},
+		{name: "flat through both arms", poly: u, a: Point{1, 3}, b: Point{8, 3}, want: false},
+		{name: "point inside", poly: u, a: Point{1, 1}, b: Point{1, 1}, want: true},
+		{name: "point in the gap", poly: u, a: Point{4, 1}, b: Point{4, 1}, want: false},
+		{name: "under the slanted edge", poly: triangle, a: Point{0, 0}, b: Point{4, 3}, want: true},
+		{name: "corner over the slanted edge", poly: triangle, a: Point{0, 0}, b: Point{5, 3}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.poly.ContainsRect(tt.a, tt.b); got != tt.want {
+				t.Errorf("Polygon.ContainsRect() = %v, want %v", got, tt.want)
+			}
+			if got := tt.poly.ContainsRect(tt.b, tt.a); got != tt.want {
+				t.Errorf("Polygon.ContainsRect() with swapped corners = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestPolygon_ContainsRectBruteForce checks every rectangle against locating every point in it
+func TestPolygon_ContainsRectBruteForce(t *testing.T) {
+	for _, poly := range []geometry.Polygon{example, u, triangle} {
+		halfSteps := doubled(poly)
+		var points []Point
+		for y := 0; y <= 9; y++ {
+			for x := 0; x <= 11; x++ {
+				points = append(points, Point{x, y})
+			}
+		}
+		for i, a := range points {
+			for _, b := range points[i:] {
+				// check every half step so the insides of cells are covered too
+				want := true
+				for y := 2 * min(a.Y, b.Y); y <= 2*max(a.Y, b.Y) && want; y++ {
+					for x := 2 * min(a.X, b.X); x <= 2*max(a.X, b.X); x++ {
+						if halfSteps.Locate(Point{x, y}) == geometry.Outside {
+							want = false
+							break
+						}
+					}
+				}
+				if got := poly.ContainsRect(a, b); got != want {
+					t.Fatalf("%v: ContainsRect(%v, %v) = %v, want %v", poly, a, b, got, want)
+				}
+			}
+		}
+	}
+}
+
+func doubled(poly geometry.Polygon) geometry.Polygon {
+	d := slices.Clone(poly)
+	for i := range d {
+		d[i] = Point{d[i].X * 2, d[i].Y * 2}
+	}
+	return d
+}
+
+func TestPolygon_RowInside(t *testing.T) {
+	tests := []struct {
+		name       string
+		y, x1, x2  int
+		wantInside bool
+	}{
+		{name: "top band", y: 1, x1: 7, x2: 11, wantInside: true},
+		{name: "top band too wide", y: 1, x1: 2, x2: 11, wantInside: false},
+		{name: "middle band", y: 3, x1: 2, x2: 11, wantInside: true},
+		{name: "bottom band", y: 5, x1: 9, x2: 11, wantInside: true},
+		{name: "bottom band reversed", y: 5, x1: 11, x2: 9, wantInside: true},
+		{name: "bottom band too wide", y: 5, x1: 7, x2: 11, wantInside: false},
+		{name: "below", y: 7, x1: 9, x2: 11, wantInside: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := example.RowInside(tt.y, tt.x1, tt.x2); got != tt.wantInside {
+				t.Errorf("Polygon.RowInside() = %v, want %v", got, tt.wantInside)
+			}
+		})
+	}
+}
+
+func TestPolygon_IsRectilinear(t *testing.T) {
+	if !example.IsRectilinear() || !u.IsRectilinear() {
+		t.Errorf("Polygon.IsRectilinear() = false for a rectilinear polygon")
+	}
+	if triangle.IsRectilinear() {
+		t.Errorf("Polygon.IsRectilinear() = true for a triangle")
+	}
+}
@@ -0,0 +1,113 @@
+// Package graph has generic graph searches over anything that can list a node's neighbors
+package graph
+
+import (
+	"iter"
+	"slices"
+)
+
+// Graph is a directed graph with weighted edges
+type Graph[N comparable] interface {
+	// Neighbors iterates over the nodes reachable from n and the cost of each edge
+	Neighbors(n N) iter.Seq2[N, int]
+}
+
+// Func adapts a function to a Graph
+type Func[N comparable] func(n N) iter.Seq2[N, int]
+
+func (f Func[N]) Neighbors(n N) iter.Seq2[N, int] {
+	return f(n)
+}
+
+// AdjacencyMap is an unweighted graph, every edge costs 1
+type AdjacencyMap[N comparable] map[N][]N
+
+func (m AdjacencyMap[N]) Neighbors(n N) iter.Seq2[N, int] {
+	return func(yield func(N, int) bool) {
+		for _, to := range m[n] {
+			if !yield(to, 1) {
+				return
+			}
+		}
+	}
+}
+
+// Edge is an edge to a node in a WeightedAdjacencyMap
+type Edge[N comparable] struct {
+	To     N
+	Weight int
+}
+
+// WeightedAdjacencyMap is a graph with a weight on each edge
+type WeightedAdjacencyMap[N comparable] map[N][]Edge[N]
+
+func (m WeightedAdjacencyMap[N]) Neighbors(n N) iter.Seq2[N, int] {
+	return func(yield func(N, int) bool) {
+		for _, e := range m[n] {
+			if !yield(e.To, e.Weight) {
+				return
+			}
+		}
+	}
+}
+
+// Visit is called as each node is reached with its distance from the start. Returning false stops the search.
+type Visit[N comparable] func(n N, dist int) bool
+
+type options[N comparable] struct {
+	visit Visit[N]
+}
+
+// Option configures a search
+type Option[N comparable] func(o *options[N])
+
+// WithVisit calls visit as each node is reached, so a day can stream the search frontier into a DayUpdate
+// or stop early once it finds what it's looking for
+func WithVisit[N comparable](visit Visit[N]) Option[N] {
+	return func(o *options[N]) {
+		o.visit = visit
+	}
+}
+
+func newOptions[N comparable](opts []Option[N]) *options[N] {
+	o := &options[N]{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// visitNode calls the visit callback, if there is one, and returns false if the search should stop
+func (o *options[N]) visitNode(n N, dist int) bool {
+	return o.visit == nil || o.visit(n, dist)
+}
+
+// Paths is the result of a search from Start. Dist has the distance to every node reached and Prev has the
+// node each was reached from.
+type Paths[N comparable] struct {
+	Start N
+	Dist  map[N]int
+	Prev  map[N]N
+}
+
+func newPaths[N comparable](start N) *Paths[N] {
+	return &Paths[N]{
+		Start: start,
+		Dist:  map[N]int{start: 0},
+		Prev:  map[N]N{},
+	}
+}
+
+// PathTo returns the path from Start to n, including both, or false if n wasn't reached
+func (p *Paths[N]) PathTo(n N) ([]N, bool) {
+	if _, ok := p.Dist[n]; !ok {
+		return nil, false
+	}
+	path := []N{n}
+	for n != p.Start {
+		n = p.Prev[n]
+		path = append(path, n)
+	}
+	slices.Reverse(path)
+	return path, true
+}
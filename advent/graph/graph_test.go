@@ -0,0 +1,237 @@
+package graph_test
+
+import (
+	"errors"
+	"iter"
+	"slices"
+	"testing"
+
+	"github.com/sirgwain/advent-of-code-2025/advent/graph"
+)
+
+// a diamond with a shortcut
+//
+//	a -> b -> d -> e
+//	a -> c -> d
+//	a ------------> e (weight 10)
+var weighted = graph.WeightedAdjacencyMap[string]{
+	"a": {{"b", 1}, {"c", 4}, {"e", 10}},
+	"b": {{"d", 5}},
+	"c": {{"d", 1}},
+	"d": {{"e", 1}},
+}
+
+var unweighted = graph.AdjacencyMap[string]{
+	"a": {"b", "c"},
+	"b": {"d"},
+	"c": {"d", "f"},
+	"d": {"e"},
+	"f": {"e"},
+}
+
+func TestBFS(t *testing.T) {
+	paths := graph.BFS(unweighted, "a")
+	want := map[string]int{"a": 0, "b": 1, "c": 1, "d": 2, "f": 2, "e": 3}
+	for n, dist := range want {
+		if got, ok := paths.Dist[n]; !ok || got != dist {
+			t.Errorf("BFS() Dist[%s] = %d, %v, want %d", n, got, ok, dist)
+		}
+	}
+	if path, ok := paths.PathTo("e"); !ok || len(path) != 4 || path[0] != "a" || path[3] != "e" {
+		t.Errorf("BFS() PathTo(e) = %v, %v", path, ok)
+	}
+	if _, ok := paths.PathTo("z"); ok {
+		t.Errorf("BFS() PathTo(z) found a path to a missing node")
+	}
+}
+
+func TestBFS_Visit(t *testing.T) {
+	var visited []string
+	graph.BFS(unweighted, "a", graph.WithVisit(func(n string, dist int) bool {
+		visited = append(visited, n)
+		return n != "d"
+	}))
+	if want := []string{"a", "b", "c", "d"}; !slices.Equal(visited, want) {
+		t.Errorf("BFS() visited %v, want %v", visited, want)
+	}
+}
+
+func TestDFS(t *testing.T) {
+	var visited []string
+	graph.DFS(unweighted, "a", graph.WithVisit(func(n string, dist int) bool {
+		visited = append(visited, n)
+		return true
+	}))
+	if want := []string{"a", "b", "d", "e", "c", "f"}; !slices.Equal(visited, want) {
+		t.Errorf("DFS() visited %v, want %v", visited, want)
+	}
+}
+
+func TestDijkstra(t *testing.T) {
+	paths := graph.Dijkstra(weighted, "a")
+	want := map[string]int{"a": 0, "b": 1, "c": 4, "d": 5, "e": 6}
+	for n, dist := range want {
+		if got := paths.Dist[n]; got != dist {
+			t.Errorf("Dijkstra() Dist[%s] = %d, want %d", n, got, dist)
+		}
+	}
+	if path, _ := paths.PathTo("e"); !slices.Equal(path, []string{"a", "c", "d", "e"}) {
+		t.Errorf("Dijkstra() PathTo(e) = %v", path)
+	}
+
+	// nodes are visited in order of distance
+	var dists []int
+	graph.Dijkstra(weighted, "a", graph.WithVisit(func(n string, dist int) bool {
+		dists = append(dists, dist)
+		return true
+	}))
+	if !slices.IsSorted(dists) || len(dists) != 5 {
+		t.Errorf("Dijkstra() visited distances %v", dists)
+	}
+}
+
+// line is an endless graph of integers, each linking to its neighbors
+var line = graph.Func[int](func(n int) iter.Seq2[int, int] {
+	return func(yield func(int, int) bool) {
+		_ = yield(n-1, 1) && yield(n+1, 1)
+	}
+})
+
+func TestAStar(t *testing.T) {
+	visited := 0
+	distance := func(n int) int { return max(100-n, n-100) }
+	path, cost, ok := graph.AStar(line, 0, 100, distance, graph.WithVisit(func(n, dist int) bool {
+		visited++
+		return true
+	}))
+	if !ok || cost != 100 || len(path) != 101 || path[100] != 100 {
+		t.Errorf("AStar() = %v, %d, %v", path, cost, ok)
+	}
+	// a perfect heuristic goes straight to the goal on an endless graph
+	if visited != 101 {
+		t.Errorf("AStar() visited %d nodes, want 101", visited)
+	}
+
+	if _, _, ok := graph.AStar(weighted, "b", "a", func(string) int { return 0 }); ok {
+		t.Errorf("AStar() found a path to an unreachable node")
+	}
+	if path, cost, ok := graph.AStar(weighted, "a", "a", func(string) int { return 0 }); !ok || cost != 0 || len(path) != 1 {
+		t.Errorf("AStar() from a node to itself = %v, %d, %v", path, cost, ok)
+	}
+}
+
+func TestAStar_InconsistentHeuristic(t *testing.T) {
+	// the cheapest path is s -> a -> c -> g, but the heuristic overrates a so c is first reached through b
+	g := graph.WeightedAdjacencyMap[string]{
+		"s": {{"a", 1}, {"b", 1}},
+		"a": {{"c", 1}},
+		"b": {{"c", 3}},
+		"c": {{"g", 3}},
+	}
+	// admissible, a is 4 from the goal, but not consistent, it drops by 4 over a single edge of 1
+	heuristic := func(n string) int {
+		if n == "a" {
+			return 4
+		}
+		return 0
+	}
+
+	visits := map[string]int{}
+	path, cost, ok := graph.AStar(g, "s", "g", heuristic, graph.WithVisit(func(n string, dist int) bool {
+		visits[n]++
+		return true
+	}))
+	if want := []string{"s", "a", "c", "g"}; !ok || cost != 5 || !slices.Equal(path, want) {
+		t.Errorf("AStar() = %v, %d, %v, want %v, 5", path, cost, ok, want)
+	}
+	if visits["c"] != 2 {
+		t.Errorf("AStar() visited c %d times, want it expanded again once the cheaper path turned up", visits["c"])
+	}
+}
+
+func TestTopoSort(t *testing.T) {
+	order, err := graph.TopoSort(unweighted, []string{"a"})
+	if err != nil {
+		t.Fatalf("TopoSort() error = %v", err)
+	}
+	if len(order) != 6 || order[0] != "a" || order[5] != "e" {
+		t.Errorf("TopoSort() = %v", order)
+	}
+	position := map[string]int{}
+	for i, n := range order {
+		position[n] = i
+	}
+	for from, links := range unweighted {
+		for _, to := range links {
+			if position[from] > position[to] {
+				t.Errorf("TopoSort() put %s after %s", from, to)
+			}
+		}
+	}
+
+	cyclic := graph.AdjacencyMap[string]{"a": {"b"}, "b": {"c"}, "c": {"a"}}
+	if _, err := graph.TopoSort(cyclic, []string{"a"}); !errors.Is(err, graph.ErrCycle) {
+		t.Errorf("TopoSort() error = %v, want ErrCycle", err)
+	}
+}
+
+func TestTopoSort_Visit(t *testing.T) {
+	// nodes are visited as they finish, sinks first
+	var visited []string
+	order, err := graph.TopoSort(unweighted, []string{"a"}, graph.WithVisit(func(n string, finished int) bool {
+		if finished != len(visited) {
+			t.Errorf("TopoSort() visited %s with %d finished, want %d", n, finished, len(visited))
+		}
+		visited = append(visited, n)
+		return true
+	}))
+	if err != nil {
+		t.Fatalf("TopoSort() error = %v", err)
+	}
+	slices.Reverse(visited)
+	if !slices.Equal(visited, order) {
+		t.Errorf("TopoSort() visited %v, want the reverse of %v", visited, order)
+	}
+
+	// stopping early returns the nodes that finished, which are everything below them
+	order, err = graph.TopoSort(unweighted, []string{"a"}, graph.WithVisit(func(n string, finished int) bool {
+		return n != "d"
+	}))
+	if want := []string{"d", "e"}; err != nil || !slices.Equal(order, want) {
+		t.Errorf("TopoSort() stopped at d = %v, %v, want %v", order, err, want)
+	}
+}
+
+func TestCountPaths(t *testing.T) {
+	tests := []struct {
+		name      string
+		start     string
+		end       string
+		waypoints []string
+		want      int
+	}{
+		{"all", "a", "e", nil, 3},
+		{"via d", "a", "e", []string{"d"}, 2},
+		{"via c", "a", "e", []string{"c"}, 2},
+		{"via c and d", "a", "e", []string{"d", "c"}, 1},
+		{"via b and f", "a", "e", []string{"b", "f"}, 0},
+		{"duplicate waypoints", "a", "e", []string{"d", "d"}, 2},
+		{"start is a waypoint", "a", "e", []string{"a"}, 3},
+		{"to itself", "c", "c", nil, 1},
+		{"unreachable", "d", "a", nil, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := graph.CountPaths(unweighted, tt.start, tt.end, tt.waypoints...)
+			if err != nil || got != tt.want {
+				t.Errorf("CountPaths() = %d, %v, want %d", got, err, tt.want)
+			}
+		})
+	}
+
+	// a cycle off to the side of the path still can't be counted
+	cyclic := graph.AdjacencyMap[string]{"a": {"b", "x"}, "b": {"c"}, "x": {"y"}, "y": {"x"}}
+	if _, err := graph.CountPaths(cyclic, "a", "c"); !errors.Is(err, graph.ErrCycle) {
+		t.Errorf("CountPaths() error = %v, want ErrCycle", err)
+	}
+}
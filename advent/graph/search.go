@@ -0,0 +1,127 @@
+package graph
+
+import (
+	"github.com/sirgwain/advent-of-code-2025/advent/pqueue"
+)
+
+// BFS searches breadth first from start. Edge weights are ignored, Dist counts edges.
+func BFS[N comparable](g Graph[N], start N, opts ...Option[N]) *Paths[N] {
+	o := newOptions(opts)
+	paths := newPaths(start)
+	if !o.visitNode(start, 0) {
+		return paths
+	}
+
+	queue := []N{start}
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		for next := range g.Neighbors(n) {
+			if _, seen := paths.Dist[next]; seen {
+				continue
+			}
+			paths.Dist[next] = paths.Dist[n] + 1
+			paths.Prev[next] = n
+			if !o.visitNode(next, paths.Dist[next]) {
+				return paths
+			}
+			queue = append(queue, next)
+		}
+	}
+	return paths
+}
+
+// DFS searches depth first from start, visiting nodes in pre-order. Dist is the depth in the search tree,
+// which isn't necessarily the shortest distance.
+func DFS[N comparable](g Graph[N], start N, opts ...Option[N]) *Paths[N] {
+	o := newOptions(opts)
+	paths := newPaths(start)
+
+	var walk func(n N) bool
+	walk = func(n N) bool {
+		if !o.visitNode(n, paths.Dist[n]) {
+			return false
+		}
+		for next := range g.Neighbors(n) {
+			if _, seen := paths.Dist[next]; seen {
+				continue
+			}
+			paths.Dist[next] = paths.Dist[n] + 1
+			paths.Prev[next] = n
+			if !walk(next) {
+				return false
+			}
+		}
+		return true
+	}
+	walk(start)
+	return paths
+}
+
+// Dijkstra finds the cheapest path from start to every reachable node. Weights must not be negative.
+// Nodes are visited in order of their final distance, so a visit callback can stop as soon as it sees the goal.
+func Dijkstra[N comparable](g Graph[N], start N, opts ...Option[N]) *Paths[N] {
+	return search(g, start, func(N) int { return 0 }, newOptions(opts))
+}
+
+// AStar finds the cheapest path from start to goal, guided by heuristic, which estimates the remaining cost
+// from a node to the goal. The heuristic must never overestimate or the path may not be the cheapest.
+// A heuristic that isn't consistent can reach a node before its cheapest path, so a node is expanded,
+// and visited, again whenever a cheaper path to it turns up.
+// It returns the path, including both ends, and its cost, or false if the goal can't be reached.
+func AStar[N comparable](g Graph[N], start, goal N, heuristic func(n N) int, opts ...Option[N]) ([]N, int, bool) {
+	o := newOptions(opts)
+	visit := o.visit
+	o.visit = func(n N, dist int) bool {
+		if visit != nil && !visit(n, dist) {
+			return false
+		}
+		return n != goal
+	}
+
+	paths := search(g, start, heuristic, o)
+	path, ok := paths.PathTo(goal)
+	if !ok {
+		return nil, 0, false
+	}
+	return path, paths.Dist[goal], true
+}
+
+type queued[N comparable] struct {
+	n        N
+	dist     int
+	estimate int
+}
+
+// search is Dijkstra's algorithm, with A*'s heuristic added to each node's priority. Nodes aren't closed
+// once expanded, a cheaper path found later queues them again. With no heuristic, or a consistent one,
+// that never happens.
+func search[N comparable](g Graph[N], start N, heuristic func(n N) int, o *options[N]) *Paths[N] {
+	paths := newPaths(start)
+
+	queue := pqueue.New(func(a, b queued[N]) bool { return a.estimate < b.estimate })
+	queue.Push(queued[N]{start, 0, heuristic(start)})
+	for {
+		q, ok := queue.Pop()
+		if !ok {
+			return paths
+		}
+		if q.dist > paths.Dist[q.n] {
+			// we found a cheaper way here after this was queued
+			continue
+		}
+		if !o.visitNode(q.n, q.dist) {
+			return paths
+		}
+
+		for next, weight := range g.Neighbors(q.n) {
+			dist := q.dist + weight
+			if best, seen := paths.Dist[next]; seen && best <= dist {
+				continue
+			}
+			paths.Dist[next] = dist
+			paths.Prev[next] = q.n
+			queue.Push(queued[N]{next, dist, dist + heuristic(next)})
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ErrCycle is returned when a graph that must be acyclic has a cycle
+var ErrCycle = errors.New("graph has a cycle")
+
+// TopoSort orders the nodes reachable from roots so every node comes before the nodes it links to.
+// It returns an error wrapping ErrCycle if the graph has a cycle.
+//
+// The sort is a depth first search, and the visit callback is called as each node finishes, after every
+// node it links to, with the number of nodes that finished before it. Nodes finish in the reverse of the
+// final order. Returning false stops the sort early, and it returns the order of the nodes finished so far.
+func TopoSort[N comparable](g Graph[N], roots []N, opts ...Option[N]) ([]N, error) {
+	o := newOptions(opts)
+
+	const (
+		unvisited = iota
+		inProgress
+		finished
+	)
+	state := map[N]int{}
+	var order []N
+
+	// errStop unwinds the walk when the visit callback stops the sort
+	errStop := errors.New("stopped")
+
+	var walk func(n N) error
+	walk = func(n N) error {
+		switch state[n] {
+		case inProgress:
+			return fmt.Errorf("%w at %v", ErrCycle, n)
+		case finished:
+			return nil
+		}
+
+		state[n] = inProgress
+		for next := range g.Neighbors(n) {
+			if err := walk(next); err != nil {
+				return err
+			}
+		}
+		state[n] = finished
+
+		// nodes finish after everything they link to, so this builds the order backwards
+		order = append(order, n)
+		if !o.visitNode(n, len(order)-1) {
+			return errStop
+		}
+		return nil
+	}
+
+	for _, root := range roots {
+		if err := walk(root); errors.Is(err, errStop) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+	}
+	slices.Reverse(order)
+	return order, nil
+}
+
+// maxWaypoints limits CountPaths, which tracks every combination of waypoints visited
+const maxWaypoints = 20
+
+// CountPaths counts the paths from start to end that pass through every waypoint, in any order. It walks the
+// graph in topological order, carrying a count for each set of waypoints seen so far, so it never enumerates
+// the paths themselves. It returns an error wrapping ErrCycle if a cycle is reachable from start.
+func CountPaths[N comparable](g Graph[N], start, end N, waypoints ...N) (int, error) {
+	bits := map[N]int{}
+	for _, w := range waypoints {
+		if _, ok := bits[w]; !ok {
+			bits[w] = 1 << len(bits)
+		}
+	}
+	if len(bits) > maxWaypoints {
+		return 0, fmt.Errorf("too many waypoints %d, max %d", len(bits), maxWaypoints)
+	}
+	all := 1<<len(bits) - 1
+
+	order, err := TopoSort(g, []N{start})
+	if err != nil {
+		return 0, err
+	}
+
+	// counts[n][seen] is the number of paths from start to n that visited the waypoints in seen
+	counts := map[N][]int{start: make([]int, all+1)}
+	counts[start][bits[start]] = 1
+	for _, n := range order {
+		if n == end {
+			return counts[n][all], nil
+		}
+		from := counts[n]
+		for next := range g.Neighbors(n) {
+			to, ok := counts[next]
+			if !ok {
+				to = make([]int, all+1)
+				counts[next] = to
+			}
+			for seen, count := range from {
+				to[seen|bits[next]] += count
+			}
+		}
+		// everything after n in the order is done with it
+		delete(counts, n)
+	}
+	return 0, nil
+}
@@ -0,0 +1,246 @@
+package advent
+
+import (
+	"io"
+	"iter"
+	"strings"
+)
+
+// Grid is a dense 2D board of width x height cells, stored row by row
+type Grid[T comparable] struct {
+	width  int
+	height int
+	cells  []T
+}
+
+// NewGrid makes an empty grid of width x height zero values
+func NewGrid[T comparable](width, height int) *Grid[T] {
+	return &Grid[T]{
+		width:  width,
+		height: height,
+		cells:  make([]T, width*height),
+	}
+}
+
+// NewGridFromRows copies rows into a grid. Short rows are padded with zero values.
+func NewGridFromRows[T comparable](rows [][]T) *Grid[T] {
+	width := 0
+	for _, row := range rows {
+		width = max(width, len(row))
+	}
+	g := NewGrid[T](width, len(rows))
+	for y, row := range rows {
+		copy(g.cells[y*width:], row)
+	}
+	return g
+}
+
+// ParseGrid builds a grid from lines of text, converting each rune with parse
+func ParseGrid[T comparable](lines []string, parse func(r rune) (T, error)) (*Grid[T], error) {
+	rows := make([][]T, len(lines))
+	for y, line := range lines {
+		rows[y] = make([]T, 0, len(line))
+		for _, r := range line {
+			v, err := parse(r)
+			if err != nil {
+				return nil, err
+			}
+			rows[y] = append(rows[y], v)
+		}
+	}
+	return NewGridFromRows(rows), nil
+}
+
+// ReadInputAsGrid reads the input as a grid of runes
+func ReadInputAsGrid(r io.Reader) (*Grid[rune], error) {
+	rows, err := ReadInputAsRunes(r)
+	if err != nil {
+		return nil, err
+	}
+	return NewGridFromRows(rows), nil
+}
+
+func (g *Grid[T]) Width() int {
+	return g.width
+}
+
+func (g *Grid[T]) Height() int {
+	return g.height
+}
+
+// InBounds is true if p is on the grid
+func (g *Grid[T]) InBounds(p Point) bool {
+	return ValidPosition(p, g.width, g.height)
+}
+
+// Get returns the value at p, or false if p is out of bounds
+func (g *Grid[T]) Get(p Point) (T, bool) {
+	if !g.InBounds(p) {
+		var zero T
+		return zero, false
+	}
+	return g.cells[p.Y*g.width+p.X], true
+}
+
+// At returns the value at p or the zero value if out of bounds
+func (g *Grid[T]) At(p Point) T {
+	v, _ := g.Get(p)
+	return v
+}
+
+// Set sets the value at p, returning false if p is out of bounds
+func (g *Grid[T]) Set(p Point, v T) bool {
+	if !g.InBounds(p) {
+		return false
+	}
+	g.cells[p.Y*g.width+p.X] = v
+	return true
+}
+
+// Fill sets every cell to v
+func (g *Grid[T]) Fill(v T) {
+	for i := range g.cells {
+		g.cells[i] = v
+	}
+}
+
+// Row returns row y. The slice shares memory with the grid.
+func (g *Grid[T]) Row(y int) []T {
+	return g.cells[y*g.width : (y+1)*g.width]
+}
+
+// Rows iterates over each row, top to bottom
+func (g *Grid[T]) Rows() iter.Seq2[int, []T] {
+	return func(yield func(int, []T) bool) {
+		for y := range g.height {
+			if !yield(y, g.Row(y)) {
+				return
+			}
+		}
+	}
+}
+
+// All iterates over every cell, left to right, top to bottom
+func (g *Grid[T]) All() iter.Seq2[Point, T] {
+	return func(yield func(Point, T) bool) {
+		for i, v := range g.cells {
+			if !yield(Point{i % g.width, i / g.width}, v) {
+				return
+			}
+		}
+	}
+}
+
+// Neighbors iterates over the in bounds cells next to p in each direction
+func (g *Grid[T]) Neighbors(p Point, directions []Direction) iter.Seq2[Point, T] {
+	return func(yield func(Point, T) bool) {
+		for _, dir := range directions {
+			n := p.Step(dir, 1)
+			v, ok := g.Get(n)
+			if !ok {
+				continue
+			}
+			if !yield(n, v) {
+				return
+			}
+		}
+	}
+}
+
+// Neighbors4 iterates over the up, right, down and left neighbors of p
+func (g *Grid[T]) Neighbors4(p Point) iter.Seq2[Point, T] {
+	return g.Neighbors(p, CardinalDirections)
+}
+
+// Neighbors8 iterates over all neighbors of p, including diagonals
+func (g *Grid[T]) Neighbors8(p Point) iter.Seq2[Point, T] {
+	return g.Neighbors(p, AdjacentDirections)
+}
+
+// Find returns the first position of v, scanning left to right, top to bottom
+func (g *Grid[T]) Find(v T) (Point, bool) {
+	for p, c := range g.All() {
+		if c == v {
+			return p, true
+		}
+	}
+	return Point{}, false
+}
+
+// FindAll returns every position of v, or false if there are none
+func (g *Grid[T]) FindAll(v T) ([]Point, bool) {
+	var points []Point
+	for p, c := range g.All() {
+		if c == v {
+			points = append(points, p)
+		}
+	}
+	return points, len(points) > 0
+}
+
+// Count counts the cells equal to v
+func (g *Grid[T]) Count(v T) int {
+	count := 0
+	for _, c := range g.cells {
+		if c == v {
+			count++
+		}
+	}
+	return count
+}
+
+// Clone makes a deep copy of the grid
+func (g *Grid[T]) Clone() *Grid[T] {
+	clone := NewGrid[T](g.width, g.height)
+	copy(clone.cells, g.cells)
+	return clone
+}
+
+// remap builds a new width x height grid, pulling each cell from the source position returned by from
+func (g *Grid[T]) remap(width, height int, from func(x, y int) Point) *Grid[T] {
+	out := NewGrid[T](width, height)
+	for y := range height {
+		for x := range width {
+			src := from(x, y)
+			out.cells[y*width+x] = g.cells[src.Y*g.width+src.X]
+		}
+	}
+	return out
+}
+
+// Transpose swaps rows and columns
+func (g *Grid[T]) Transpose() *Grid[T] {
+	return g.remap(g.height, g.width, func(x, y int) Point { return Point{y, x} })
+}
+
+// RotateClockwise returns the grid rotated 90 degrees clockwise
+func (g *Grid[T]) RotateClockwise() *Grid[T] {
+	return g.remap(g.height, g.width, func(x, y int) Point { return Point{y, g.height - 1 - x} })
+}
+
+// RotateCounterClockwise returns the grid rotated 90 degrees counter clockwise
+func (g *Grid[T]) RotateCounterClockwise() *Grid[T] {
+	return g.remap(g.height, g.width, func(x, y int) Point { return Point{g.width - 1 - y, x} })
+}
+
+// FlipHorizontal mirrors the grid left to right
+func (g *Grid[T]) FlipHorizontal() *Grid[T] {
+	return g.remap(g.width, g.height, func(x, y int) Point { return Point{g.width - 1 - x, y} })
+}
+
+// FlipVertical mirrors the grid top to bottom
+func (g *Grid[T]) FlipVertical() *Grid[T] {
+	return g.remap(g.width, g.height, func(x, y int) Point { return Point{x, g.height - 1 - y} })
+}
+
+// Render draws the grid, one line per row, using cell to render each cell
+func (g *Grid[T]) Render(cell func(p Point, v T) string) string {
+	var sb strings.Builder
+	for p, v := range g.All() {
+		sb.WriteString(cell(p, v))
+		if p.X == g.width-1 {
+			sb.WriteRune('\n')
+		}
+	}
+	return sb.String()
+}
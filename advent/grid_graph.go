@@ -0,0 +1,47 @@
+package advent
+
+import (
+	"iter"
+
+	"github.com/sirgwain/advent-of-code-2025/advent/graph"
+)
+
+// GridGraph adapts a Grid to a graph.Graph, moving between neighboring cells
+type GridGraph[T comparable] struct {
+	Grid *Grid[T]
+
+	// Directions are the moves allowed from each cell, CardinalDirections if empty
+	Directions []Direction
+
+	// Cost returns the cost of moving between two neighboring cells, or false if the move isn't allowed.
+	// If it's nil, every move costs 1.
+	Cost func(from, to Point) (int, bool)
+}
+
+var _ graph.Graph[Point] = GridGraph[rune]{}
+
+// Graph makes a graph over the grid that moves in directions wherever cost allows
+func (g *Grid[T]) Graph(directions []Direction, cost func(from, to Point) (int, bool)) GridGraph[T] {
+	return GridGraph[T]{Grid: g, Directions: directions, Cost: cost}
+}
+
+func (gg GridGraph[T]) Neighbors(p Point) iter.Seq2[Point, int] {
+	directions := gg.Directions
+	if len(directions) == 0 {
+		directions = CardinalDirections
+	}
+	return func(yield func(Point, int) bool) {
+		for n := range gg.Grid.Neighbors(p, directions) {
+			cost := 1
+			if gg.Cost != nil {
+				var ok bool
+				if cost, ok = gg.Cost(p, n); !ok {
+					continue
+				}
+			}
+			if !yield(n, cost) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package advent
+
+import (
+	"testing"
+
+	"github.com/sirgwain/advent-of-code-2025/advent/graph"
+)
+
+func TestGridGraph(t *testing.T) {
+	maze, _ := ParseGrid([]string{
+		"S.#....",
+		".##.##.",
+		"....#E.",
+	}, func(r rune) (rune, error) { return r, nil })
+	start, _ := maze.Find('S')
+	end, _ := maze.Find('E')
+
+	open := maze.Graph(CardinalDirections, func(from, to Point) (int, bool) {
+		return 1, maze.At(to) != '#'
+	})
+
+	paths := graph.BFS(open, start)
+	if got := paths.Dist[end]; got != 13 {
+		t.Errorf("BFS() through the maze = %d, want 13", got)
+	}
+
+	// diagonal moves cut the corners
+	diagonal := open
+	diagonal.Directions = AdjacentDirections
+	if got := graph.BFS(diagonal, start).Dist[end]; got != 8 {
+		t.Errorf("BFS() through the maze with diagonals = %d, want 8", got)
+	}
+
+	// walls are expensive instead of blocked
+	walls := maze.Graph(nil, func(from, to Point) (int, bool) {
+		if maze.At(to) == '#' {
+			return 5, true
+		}
+		return 1, true
+	})
+	path, cost, ok := graph.AStar(walls, start, end, func(p Point) int { return p.Manhattan(end) })
+	if !ok || cost != 11 {
+		t.Errorf("AStar() through the walls = %v, %d, %v, want cost 11", path, cost, ok)
+	}
+}
@@ -0,0 +1,125 @@
+package advent
+
+import (
+	"maps"
+	"slices"
+	"testing"
+)
+
+func testGrid() *Grid[rune] {
+	// abc
+	// def
+	g, _ := ParseGrid([]string{"abc", "def"}, func(r rune) (rune, error) { return r, nil })
+	return g
+}
+
+func gridString(g *Grid[rune]) string {
+	return g.Render(func(p Point, r rune) string { return string(r) })
+}
+
+func TestGrid_Get(t *testing.T) {
+	g := testGrid()
+	tests := []struct {
+		name   string
+		p      Point
+		want   rune
+		wantOk bool
+	}{
+		{name: "top left", p: Point{0, 0}, want: 'a', wantOk: true},
+		{name: "bottom right", p: Point{2, 1}, want: 'f', wantOk: true},
+		{name: "left of grid", p: Point{-1, 0}, want: 0, wantOk: false},
+		{name: "right of grid", p: Point{3, 0}, want: 0, wantOk: false},
+		{name: "below grid", p: Point{0, 2}, want: 0, wantOk: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotOk := g.Get(tt.p)
+			if got != tt.want || gotOk != tt.wantOk {
+				t.Errorf("Grid.Get() = %c, %v, want %c, %v", got, gotOk, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestGrid_Set(t *testing.T) {
+	g := testGrid()
+	if !g.Set(Point{1, 1}, 'x') {
+		t.Errorf("Grid.Set() in bounds returned false")
+	}
+	if g.Set(Point{3, 1}, 'x') {
+		t.Errorf("Grid.Set() out of bounds returned true")
+	}
+	if got, want := gridString(g), "abc\ndxf\n"; got != want {
+		t.Errorf("Grid.Set() = %q, want %q", got, want)
+	}
+}
+
+func TestGrid_Find(t *testing.T) {
+	g, _ := ParseGrid([]string{"S..", "..S"}, func(r rune) (rune, error) { return r, nil })
+
+	if p, ok := g.Find('S'); !ok || p != (Point{0, 0}) {
+		t.Errorf("Grid.Find() = %v, %v, want 0, 0, true", p, ok)
+	}
+	if _, ok := g.Find('x'); ok {
+		t.Errorf("Grid.Find() found a missing value")
+	}
+	if points, ok := g.FindAll('S'); !ok || !slices.Equal(points, []Point{{0, 0}, {2, 1}}) {
+		t.Errorf("Grid.FindAll() = %v, %v", points, ok)
+	}
+	if _, ok := g.FindAll('x'); ok {
+		t.Errorf("Grid.FindAll() found a missing value")
+	}
+}
+
+func TestGrid_Neighbors(t *testing.T) {
+	g := NewGrid[int](3, 3)
+	tests := []struct {
+		name       string
+		p          Point
+		directions []Direction
+		want       int
+	}{
+		{name: "center 4", p: Point{1, 1}, directions: CardinalDirections, want: 4},
+		{name: "center 8", p: Point{1, 1}, directions: AdjacentDirections, want: 8},
+		{name: "corner 4", p: Point{0, 0}, directions: CardinalDirections, want: 2},
+		{name: "corner 8", p: Point{0, 0}, directions: AdjacentDirections, want: 3},
+		{name: "edge 8", p: Point{1, 0}, directions: AdjacentDirections, want: 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := maps.Collect(g.Neighbors(tt.p, tt.directions))
+			if len(got) != tt.want {
+				t.Errorf("Grid.Neighbors() = %v, want %d neighbors", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGrid_Transforms(t *testing.T) {
+	tests := []struct {
+		name      string
+		transform func(g *Grid[rune]) *Grid[rune]
+		want      string
+	}{
+		{name: "transpose", transform: (*Grid[rune]).Transpose, want: "ad\nbe\ncf\n"},
+		{name: "rotate clockwise", transform: (*Grid[rune]).RotateClockwise, want: "da\neb\nfc\n"},
+		{name: "rotate counter clockwise", transform: (*Grid[rune]).RotateCounterClockwise, want: "cf\nbe\nad\n"},
+		{name: "flip horizontal", transform: (*Grid[rune]).FlipHorizontal, want: "cba\nfed\n"},
+		{name: "flip vertical", transform: (*Grid[rune]).FlipVertical, want: "def\nabc\n"},
+		{name: "clone", transform: (*Grid[rune]).Clone, want: "abc\ndef\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := testGrid()
+			got := tt.transform(g)
+			if s := gridString(got); s != tt.want {
+				t.Errorf("%s = %q, want %q", tt.name, s, tt.want)
+			}
+			// transforms never modify the original
+			got.Set(Point{0, 0}, 'x')
+			if s := gridString(g); s != "abc\ndef\n" {
+				t.Errorf("%s modified the original grid: %q", tt.name, s)
+			}
+		})
+	}
+}
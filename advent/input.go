@@ -3,20 +3,52 @@ package advent
 import (
 	"bufio"
 	"fmt"
-	"os"
+	"io"
 	"strconv"
+	"strings"
+
+	"github.com/sirgwain/advent-of-code-2025/advent/parse"
 )
 
-// read input as a series of rune lines
-func ReadInputAsRunes(filename string) ([][]rune, error) {
-	file, err := os.Open(filename)
+// readInput reads the input for a day, every day needs at least one line. name is where it came from, for errors.
+func readInput(r io.Reader, name string) (*parse.Input, error) {
+	in, err := parse.Read(name, r)
 	if err != nil {
-		return nil, fmt.Errorf("error opening file: %w", err)
+		return nil, err
+	}
+	if len(in.Lines) == 0 {
+		return nil, in.Errorf("input is empty")
+	}
+	return in, nil
+}
+
+// readGrid reads the input as a grid of runes, each one of allowed. Short rows are padded with zero values.
+func readGrid(r io.Reader, name string, allowed string) (*Grid[rune], error) {
+	in, err := readInput(r, name)
+	if err != nil {
+		return nil, err
+	}
+	return parseGrid(in, allowed)
+}
+
+// parseGrid is readGrid for input that has already been read, for days that check the grid against its lines
+func parseGrid(in *parse.Input, allowed string) (*Grid[rune], error) {
+	rows := make([][]rune, len(in.Lines))
+	for y, line := range in.Lines {
+		rows[y] = []rune(line.Text)
+		for x, r := range rows[y] {
+			if !strings.ContainsRune(allowed, r) {
+				return nil, line.Errorf(x+1, "expected one of %q, not %q", allowed, r)
+			}
+		}
 	}
-	defer file.Close()
+	return NewGridFromRows(rows), nil
+}
 
+// read input as a series of rune lines
+func ReadInputAsRunes(r io.Reader) ([][]rune, error) {
 	var input [][]rune
-	scanner := bufio.NewScanner(file)
+	scanner := bufio.NewScanner(r)
 	for scanner.Scan() {
 
 		line := scanner.Text()
@@ -24,27 +56,22 @@ func ReadInputAsRunes(filename string) ([][]rune, error) {
 	}
 
 	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("error reading file: %w", err)
+		return nil, fmt.Errorf("error reading input: %w", err)
 	}
 
 	return input, nil
 }
 
-// read input as a series of rune lines
-func ReadInputAsIntBoard(filename string) ([][]int, error) {
-	file, err := os.Open(filename)
-	if err != nil {
-		return nil, fmt.Errorf("error opening file: %w", err)
-	}
-	defer file.Close()
-
+// read input as a board of single digit numbers
+func ReadInputAsIntBoard(r io.Reader) ([][]int, error) {
 	var input [][]int
-	scanner := bufio.NewScanner(file)
+	scanner := bufio.NewScanner(r)
 	lineNum := 0
 	for scanner.Scan() {
 		line := scanner.Text()
 		input = append(input, make([]int, len(line)))
 		for i, r := range []rune(line) {
+			var err error
 			input[lineNum][i], err = strconv.Atoi(string(r))
 			if err != nil {
 				return nil, fmt.Errorf("%v is not a number %w", r, err)
@@ -54,7 +81,7 @@ func ReadInputAsIntBoard(filename string) ([][]int, error) {
 	}
 
 	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("error reading file: %w", err)
+		return nil, fmt.Errorf("error reading input: %w", err)
 	}
 
 	return input, nil
@@ -0,0 +1,179 @@
+package advent
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+	"sort"
+)
+
+// Integer is any integer type
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Interval is an inclusive range of integers, Low..High
+type Interval[T Integer] struct {
+	Low  T
+	High T
+}
+
+// Empty is true if the interval doesn't contain any values
+func (in Interval[T]) Empty() bool {
+	return in.Low > in.High
+}
+
+// Length is the number of values in the interval
+func (in Interval[T]) Length() T {
+	if in.Empty() {
+		return 0
+	}
+	return in.High - in.Low + 1
+}
+
+// Contains is true if v is in the interval
+func (in Interval[T]) Contains(v T) bool {
+	return v >= in.Low && v <= in.High
+}
+
+func (in Interval[T]) String() string {
+	return fmt.Sprintf("%d..%d", in.Low, in.High)
+}
+
+// before is true if in ends with at least one value between it and other, so they can't merge
+func (in Interval[T]) before(other Interval[T]) bool {
+	// the first check keeps other.Low-1 from underflowing
+	return in.High < other.Low && in.High < other.Low-1
+}
+
+// IntervalSet is a set of integers stored as sorted, non overlapping intervals.
+// Intervals that overlap or touch, like 1..3 and 4..6, are merged as they are inserted.
+type IntervalSet[T Integer] struct {
+	intervals []Interval[T]
+}
+
+// NewIntervalSet makes a set containing every value in intervals
+func NewIntervalSet[T Integer](intervals ...Interval[T]) *IntervalSet[T] {
+	s := &IntervalSet[T]{}
+	for _, in := range intervals {
+		s.Insert(in)
+	}
+	return s
+}
+
+// Insert adds every value of in to the set, merging it with any intervals it overlaps or touches.
+// It returns the interval in ended up in and true if it merged with an existing interval.
+// Empty intervals are ignored.
+func (s *IntervalSet[T]) Insert(in Interval[T]) (Interval[T], bool) {
+	if in.Empty() {
+		return in, false
+	}
+
+	// intervals [i, j) overlap or touch in
+	i := sort.Search(len(s.intervals), func(k int) bool { return !s.intervals[k].before(in) })
+	j := sort.Search(len(s.intervals), func(k int) bool { return in.before(s.intervals[k]) })
+
+	merged := in
+	if i < j {
+		merged.Low = min(merged.Low, s.intervals[i].Low)
+		merged.High = max(merged.High, s.intervals[j-1].High)
+	}
+	s.intervals = slices.Replace(s.intervals, i, j, merged)
+	return merged, i < j
+}
+
+// Contains is true if v is in the set
+func (s *IntervalSet[T]) Contains(v T) bool {
+	i := sort.Search(len(s.intervals), func(k int) bool { return s.intervals[k].High >= v })
+	return i < len(s.intervals) && s.intervals[i].Low <= v
+}
+
+// Len is the number of intervals in the set
+func (s *IntervalSet[T]) Len() int {
+	return len(s.intervals)
+}
+
+// Length is the number of values in the set
+func (s *IntervalSet[T]) Length() T {
+	var length T
+	for _, in := range s.intervals {
+		length += in.Length()
+	}
+	return length
+}
+
+// All iterates over the intervals in order
+func (s *IntervalSet[T]) All() iter.Seq2[int, Interval[T]] {
+	return slices.All(s.intervals)
+}
+
+// Intervals returns a copy of the intervals in order
+func (s *IntervalSet[T]) Intervals() []Interval[T] {
+	return slices.Clone(s.intervals)
+}
+
+// Clone makes a copy of the set
+func (s *IntervalSet[T]) Clone() *IntervalSet[T] {
+	return &IntervalSet[T]{intervals: s.Intervals()}
+}
+
+// Union returns a new set with the values in either set
+func (s *IntervalSet[T]) Union(other *IntervalSet[T]) *IntervalSet[T] {
+	union := s.Clone()
+	for _, in := range other.intervals {
+		union.Insert(in)
+	}
+	return union
+}
+
+// Intersect returns a new set with the values in both sets
+func (s *IntervalSet[T]) Intersect(other *IntervalSet[T]) *IntervalSet[T] {
+	intersection := &IntervalSet[T]{}
+	a, b := s.intervals, other.intervals
+	for len(a) > 0 && len(b) > 0 {
+		in := Interval[T]{max(a[0].Low, b[0].Low), min(a[0].High, b[0].High)}
+		if !in.Empty() {
+			intersection.intervals = append(intersection.intervals, in)
+		}
+		// drop whichever interval ends first, the other may still overlap the next one
+		if a[0].High < b[0].High {
+			a = a[1:]
+		} else {
+			b = b[1:]
+		}
+	}
+	return intersection
+}
+
+// Subtract returns a new set with the values in s that aren't in other
+func (s *IntervalSet[T]) Subtract(other *IntervalSet[T]) *IntervalSet[T] {
+	difference := &IntervalSet[T]{}
+	cuts := other.intervals
+	for _, in := range s.intervals {
+		// skip cuts that end before this interval
+		for len(cuts) > 0 && cuts[0].High < in.Low {
+			cuts = cuts[1:]
+		}
+
+		low := in.Low
+		covered := false
+		for _, cut := range cuts {
+			if cut.Low > in.High {
+				break
+			}
+			if cut.Low > low {
+				difference.intervals = append(difference.intervals, Interval[T]{low, cut.Low - 1})
+			}
+			if cut.High >= in.High {
+				covered = true
+				break
+			}
+			low = cut.High + 1
+		}
+		if !covered {
+			difference.intervals = append(difference.intervals, Interval[T]{low, in.High})
+		}
+	}
+	return difference
+}
@@ -0,0 +1,102 @@
+package advent
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+type int64Interval = Interval[int64]
+
+func TestIntervalSet_Insert(t *testing.T) {
+	tests := []struct {
+		name       string
+		r1         int64Interval
+		r2         int64Interval
+		want       []int64Interval
+		wantMerged bool
+	}{
+		{name: "no overlap low", r1: int64Interval{3, 5}, r2: int64Interval{10, 14}, want: []int64Interval{{3, 5}, {10, 14}}},
+		{name: "no overlap high", r1: int64Interval{10, 14}, r2: int64Interval{3, 5}, want: []int64Interval{{3, 5}, {10, 14}}},
+		{name: "low overlap", r1: int64Interval{3, 12}, r2: int64Interval{10, 14}, want: []int64Interval{{3, 14}}, wantMerged: true},
+		{name: "high overlap", r1: int64Interval{12, 15}, r2: int64Interval{10, 14}, want: []int64Interval{{10, 15}}, wantMerged: true},
+		{name: "r1 in r2", r1: int64Interval{12, 13}, r2: int64Interval{10, 14}, want: []int64Interval{{10, 14}}, wantMerged: true},
+		{name: "r2 in r1", r1: int64Interval{10, 14}, r2: int64Interval{12, 13}, want: []int64Interval{{10, 14}}, wantMerged: true},
+		{name: "r1 high one away from r2 low", r1: int64Interval{10, 14}, r2: int64Interval{15, 16}, want: []int64Interval{{10, 16}}, wantMerged: true},
+		{name: "r1 low one away from r2 high", r1: int64Interval{10, 14}, r2: int64Interval{5, 9}, want: []int64Interval{{5, 14}}, wantMerged: true},
+		{name: "two away", r1: int64Interval{10, 14}, r2: int64Interval{16, 20}, want: []int64Interval{{10, 14}, {16, 20}}},
+		{name: "empty", r1: int64Interval{10, 14}, r2: int64Interval{20, 16}, want: []int64Interval{{10, 14}}},
+		{name: "extremes", r1: int64Interval{math.MinInt64, 0}, r2: int64Interval{1, math.MaxInt64}, want: []int64Interval{{math.MinInt64, math.MaxInt64}}, wantMerged: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewIntervalSet(tt.r1)
+			if _, merged := s.Insert(tt.r2); merged != tt.wantMerged {
+				t.Errorf("IntervalSet.Insert() merged = %v, want %v", merged, tt.wantMerged)
+			}
+			if got := s.Intervals(); !slices.Equal(got, tt.want) {
+				t.Errorf("IntervalSet.Insert() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIntervalSet_InsertMany(t *testing.T) {
+	// one interval bridging several others merges them all
+	s := NewIntervalSet[int](Interval[int]{1, 2}, Interval[int]{5, 6}, Interval[int]{9, 10}, Interval[int]{20, 30})
+	merged, ok := s.Insert(Interval[int]{3, 11})
+	if !ok || merged != (Interval[int]{1, 11}) {
+		t.Errorf("IntervalSet.Insert() = %v, %v, want 1..11, true", merged, ok)
+	}
+	if got, want := s.Intervals(), []Interval[int]{{1, 11}, {20, 30}}; !slices.Equal(got, want) {
+		t.Errorf("IntervalSet.Insert() = %v, want %v", got, want)
+	}
+	if got := s.Length(); got != 22 {
+		t.Errorf("IntervalSet.Length() = %v, want 22", got)
+	}
+}
+
+func TestIntervalSet_Contains(t *testing.T) {
+	s := NewIntervalSet(int64Interval{3, 5}, int64Interval{10, 14}, int64Interval{16, 20}, int64Interval{12, 18})
+	tests := []struct {
+		v    int64
+		want bool
+	}{
+		{1, false}, {3, true}, {5, true}, {6, false}, {8, false}, {11, true}, {17, true}, {20, true}, {21, false},
+	}
+	for _, tt := range tests {
+		if got := s.Contains(tt.v); got != tt.want {
+			t.Errorf("IntervalSet.Contains(%d) = %v, want %v", tt.v, got, tt.want)
+		}
+	}
+}
+
+func TestIntervalSet_SetOperations(t *testing.T) {
+	a := NewIntervalSet[int](Interval[int]{0, 10}, Interval[int]{20, 30}, Interval[int]{40, 50})
+	b := NewIntervalSet[int](Interval[int]{5, 25}, Interval[int]{28, 28}, Interval[int]{45, 60})
+
+	tests := []struct {
+		name string
+		got  *IntervalSet[int]
+		want []Interval[int]
+	}{
+		{name: "union", got: a.Union(b), want: []Interval[int]{{0, 30}, {40, 60}}},
+		{name: "intersect", got: a.Intersect(b), want: []Interval[int]{{5, 10}, {20, 25}, {28, 28}, {45, 50}}},
+		{name: "subtract", got: a.Subtract(b), want: []Interval[int]{{0, 4}, {26, 27}, {29, 30}, {40, 44}}},
+		{name: "subtract reverse", got: b.Subtract(a), want: []Interval[int]{{11, 19}, {51, 60}}},
+		{name: "subtract everything", got: a.Subtract(NewIntervalSet(Interval[int]{-5, 100})), want: nil},
+		{name: "intersect nothing", got: a.Intersect(&IntervalSet[int]{}), want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.got.Intervals(); !slices.Equal(got, tt.want) {
+				t.Errorf("%s = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+
+	// set operations don't modify their inputs
+	if got := a.Intervals(); !slices.Equal(got, []Interval[int]{{0, 10}, {20, 30}, {40, 50}}) {
+		t.Errorf("set operations modified the set: %v", got)
+	}
+}
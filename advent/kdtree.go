@@ -0,0 +1,194 @@
+package advent
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+
+	"github.com/sirgwain/advent-of-code-2025/advent/pqueue"
+)
+
+// KDTree3 indexes points in 3D space for nearest neighbor searches
+type KDTree3 struct {
+	points []Point3
+	nodes  []kdNode
+	root   int
+}
+
+type kdNode struct {
+	index int // index into points
+	axis  int // 0, 1 or 2 for x, y or z
+	left  int // index into nodes, or -1
+	right int
+}
+
+// Neighbor is a point found by a nearest neighbor search
+type Neighbor struct {
+	Index       int
+	DistSquared int64
+}
+
+// compareNeighbors orders neighbors by distance, then index, so searches are deterministic when distances tie
+func compareNeighbors(a, b Neighbor) int {
+	return cmp.Or(cmp.Compare(a.DistSquared, b.DistSquared), cmp.Compare(a.Index, b.Index))
+}
+
+// NewKDTree3 builds a balanced tree over points. The points aren't copied or reordered,
+// searches return indexes into them.
+func NewKDTree3(points []Point3) *KDTree3 {
+	t := &KDTree3{points: points, nodes: make([]kdNode, 0, len(points))}
+	indexes := make([]int, len(points))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	t.root = t.build(indexes, 0)
+	return t
+}
+
+// build splits indexes at the median along axis and returns the node for the median
+func (t *KDTree3) build(indexes []int, axis int) int {
+	if len(indexes) == 0 {
+		return -1
+	}
+	slices.SortFunc(indexes, func(a, b int) int {
+		return cmp.Compare(coord(t.points[a], axis), coord(t.points[b], axis))
+	})
+	mid := len(indexes) / 2
+
+	n := len(t.nodes)
+	t.nodes = append(t.nodes, kdNode{index: indexes[mid], axis: axis})
+	next := (axis + 1) % 3
+	left := t.build(indexes[:mid], next)
+	right := t.build(indexes[mid+1:], next)
+	t.nodes[n].left, t.nodes[n].right = left, right
+	return n
+}
+
+func coord(p Point3, axis int) int {
+	switch axis {
+	case 0:
+		return p.X
+	case 1:
+		return p.Y
+	}
+	return p.Z
+}
+
+// Nearest finds the k points closest to p for which accept returns true, closest first. Ties are broken by
+// index, so asking for more neighbors always returns the same ones first. A nil accept accepts every point.
+func (t *KDTree3) Nearest(p Point3, k int, accept func(i int) bool) []Neighbor {
+	if k <= 0 {
+		return nil
+	}
+
+	// keep the best k in a heap with the worst on top, so it's easy to replace
+	best := pqueue.New(func(a, b Neighbor) bool { return compareNeighbors(a, b) > 0 })
+	var search func(n int)
+	search = func(n int) {
+		if n == -1 {
+			return
+		}
+		node := t.nodes[n]
+		if accept == nil || accept(node.index) {
+			candidate := Neighbor{node.index, p.DistSquared(t.points[node.index])}
+			if best.Len() < k {
+				best.Push(candidate)
+			} else if worst, _ := best.Peek(); compareNeighbors(candidate, worst) < 0 {
+				best.Pop()
+				best.Push(candidate)
+			}
+		}
+
+		// search the side p is on first, then the far side if it could still hold something closer
+		diff := int64(coord(p, node.axis) - coord(t.points[node.index], node.axis))
+		near, far := node.left, node.right
+		if diff > 0 {
+			near, far = far, near
+		}
+		search(near)
+		if worst, _ := best.Peek(); best.Len() < k || diff*diff <= worst.DistSquared {
+			search(far)
+		}
+	}
+	search(t.root)
+
+	neighbors := make([]Neighbor, best.Len())
+	for i := len(neighbors) - 1; i >= 0; i-- {
+		neighbors[i], _ = best.Pop()
+	}
+	return neighbors
+}
+
+// PointPair is a pair of points, by index, and the squared distance between them
+type PointPair struct {
+	A           int
+	B           int
+	DistSquared int64
+}
+
+// pairCursor walks the pairs of one point with every later point, closest first, fetching neighbors in batches
+type pairCursor struct {
+	index     int
+	batch     []Neighbor
+	k         int
+	exhausted bool
+}
+
+// closestPairsBatch is how many neighbors each point fetches at first. Most points only ever need a few.
+const closestPairsBatch = 8
+
+// next returns the next closest later point. Each fetch asks for twice as many neighbors as the last and
+// skips the ones already seen, which works because Nearest always returns them in the same order.
+func (c *pairCursor) next(tree *KDTree3) (Neighbor, bool) {
+	if len(c.batch) == 0 && !c.exhausted {
+		seen := c.k
+		c.k = max(c.k*2, closestPairsBatch)
+		found := tree.Nearest(tree.points[c.index], c.k, func(i int) bool { return i > c.index })
+		c.exhausted = len(found) < c.k
+		c.batch = found[min(seen, len(found)):]
+	}
+	if len(c.batch) == 0 {
+		return Neighbor{}, false
+	}
+	n := c.batch[0]
+	c.batch = c.batch[1:]
+	return n, true
+}
+
+// comparePairs orders pairs by distance, then by index
+func comparePairs(a, b PointPair) int {
+	return cmp.Or(cmp.Compare(a.DistSquared, b.DistSquared), cmp.Compare(a.A, b.A), cmp.Compare(a.B, b.B))
+}
+
+// ClosestPairs yields every pair of points in order of increasing distance, ties broken by index. Pairs are found
+// lazily, so stopping early only costs a few nearest neighbor searches per point instead of sorting all n² pairs.
+func ClosestPairs(points []Point3) iter.Seq[PointPair] {
+	type head struct {
+		pair   PointPair
+		cursor *pairCursor
+	}
+
+	return func(yield func(PointPair) bool) {
+		tree := NewKDTree3(points)
+
+		// each point pairs only with the points after it, so every pair comes from exactly one cursor.
+		// The queue holds the closest unseen pair from each cursor, so its top is the closest pair overall.
+		heads := pqueue.New(func(a, b head) bool { return comparePairs(a.pair, b.pair) < 0 })
+		push := func(c *pairCursor) {
+			if n, ok := c.next(tree); ok {
+				heads.Push(head{PointPair{c.index, n.Index, n.DistSquared}, c})
+			}
+		}
+		for i := range points {
+			push(&pairCursor{index: i})
+		}
+
+		for {
+			h, ok := heads.Pop()
+			if !ok || !yield(h.pair) {
+				return
+			}
+			push(h.cursor)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package advent
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// randomPoints3 makes n points in a cube of the given size. Small cubes have lots of tied distances.
+func randomPoints3(seed uint64, n, size int) []Point3 {
+	r := rand.New(rand.NewPCG(seed, seed))
+	points := make([]Point3, n)
+	for i := range points {
+		points[i] = Point3{r.IntN(size), r.IntN(size), r.IntN(size)}
+	}
+	return points
+}
+
+// allPairs is the brute force version of ClosestPairs
+func allPairs(points []Point3) []PointPair {
+	var pairs []PointPair
+	for i := range points {
+		for j := i + 1; j < len(points); j++ {
+			pairs = append(pairs, PointPair{i, j, points[i].DistSquared(points[j])})
+		}
+	}
+	slices.SortFunc(pairs, comparePairs)
+	return pairs
+}
+
+func TestKDTree3_Nearest(t *testing.T) {
+	points := randomPoints3(1, 200, 10)
+	tree := NewKDTree3(points)
+	odd := func(i int) bool { return i%2 == 1 }
+
+	for _, p := range randomPoints3(2, 20, 12) {
+		var want []Neighbor
+		for i, q := range points {
+			if odd(i) {
+				want = append(want, Neighbor{i, p.DistSquared(q)})
+			}
+		}
+		slices.SortFunc(want, compareNeighbors)
+
+		for _, k := range []int{1, 5, 50, 500} {
+			if got := tree.Nearest(p, k, odd); !slices.Equal(got, want[:min(k, len(want))]) {
+				t.Errorf("Nearest(%v, %d) = %v, want %v", p, k, got, want[:min(k, len(want))])
+			}
+		}
+	}
+}
+
+func TestClosestPairs(t *testing.T) {
+	tests := []struct {
+		name   string
+		points []Point3
+	}{
+		{"empty", nil},
+		{"one", []Point3{{1, 2, 3}}},
+		{"duplicates", []Point3{{1, 1, 1}, {1, 1, 1}, {0, 0, 0}, {1, 1, 1}}},
+		{"example", parsePoints3(t, day8Example)},
+		{"ties", randomPoints3(3, 60, 4)},
+		{"spread", randomPoints3(4, 150, 1000)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := allPairs(tt.points)
+			got := slices.Collect(ClosestPairs(tt.points))
+			if !slices.Equal(got, want) {
+				t.Errorf("ClosestPairs() returned %d pairs, want %d in order", len(got), len(want))
+			}
+		})
+	}
+}
+
+func parsePoints3(t *testing.T, lines []string) []Point3 {
+	points := make([]Point3, len(lines))
+	for i, line := range lines {
+		if err := points[i].UnmarshalText([]byte(line)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return points
+}
+
+func BenchmarkClosestPairs(b *testing.B) {
+	points := randomPoints3(5, 2000, 100000)
+	first := len(points) * 4
+
+	b.Run("sorted", func(b *testing.B) {
+		for b.Loop() {
+			_ = allPairs(points)[:first]
+		}
+	})
+	b.Run("kdtree", func(b *testing.B) {
+		for b.Loop() {
+			count := 0
+			for range ClosestPairs(points) {
+				if count++; count == first {
+					break
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,134 @@
+// Package linalg solves systems of linear equations
+package linalg
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"math/bits"
+)
+
+// ErrNoSolution is returned when a system of equations can't be satisfied
+var ErrNoSolution = errors.New("no solution")
+
+// MaxGF2Columns is the most variables SolveGF2 handles, each solution is a bitmask of them
+const MaxGF2Columns = 64
+
+// GF2Solution is every solution to a system over GF(2), where adding is xor. Any solution is Particular
+// xored with some combination of the NullSpace vectors.
+type GF2Solution struct {
+	Particular uint64
+	NullSpace  []uint64
+}
+
+// SolveGF2 solves Ax = b over GF(2) by Gaussian elimination. Column j of A is the bitmask columns[j], with bit i
+// set if row i has a 1 in that column, and the solution has bit j set if x[j] is 1. It returns an error
+// wrapping ErrNoSolution if nothing satisfies the system.
+func SolveGF2(columns []uint64, b uint64) (GF2Solution, error) {
+	if len(columns) > MaxGF2Columns {
+		return GF2Solution{}, fmt.Errorf("too many columns %d, max %d", len(columns), MaxGF2Columns)
+	}
+
+	// transpose into rows of the augmented matrix, so each row is one equation
+	numRows := bits.Len64(b)
+	for _, c := range columns {
+		numRows = max(numRows, bits.Len64(c))
+	}
+	rows := make([]uint64, numRows)
+	rhs := make([]bool, numRows)
+	for i := range rows {
+		for j, c := range columns {
+			if c&(1<<i) != 0 {
+				rows[i] |= 1 << j
+			}
+		}
+		rhs[i] = b&(1<<i) != 0
+	}
+
+	// reduce to reduced row echelon form, pivots[r] is the column row r solves for
+	var pivots []int
+	var pivotColumns uint64
+	r := 0
+	for col := range columns {
+		pivot := -1
+		for i := r; i < numRows; i++ {
+			if rows[i]&(1<<col) != 0 {
+				pivot = i
+				break
+			}
+		}
+		if pivot == -1 {
+			// a free column
+			continue
+		}
+		rows[r], rows[pivot] = rows[pivot], rows[r]
+		rhs[r], rhs[pivot] = rhs[pivot], rhs[r]
+		for i := range rows {
+			if i != r && rows[i]&(1<<col) != 0 {
+				rows[i] ^= rows[r]
+				rhs[i] = rhs[i] != rhs[r]
+			}
+		}
+		pivots = append(pivots, col)
+		pivotColumns |= 1 << col
+		r++
+	}
+
+	// any row left over is all zeros, so it has to equal zero too
+	for i := r; i < numRows; i++ {
+		if rhs[i] {
+			return GF2Solution{}, ErrNoSolution
+		}
+	}
+
+	// set the free columns to 0, then each pivot column is whatever its row needs
+	var s GF2Solution
+	for i, col := range pivots {
+		if rhs[i] {
+			s.Particular |= 1 << col
+		}
+	}
+
+	// setting one free column to 1 flips every pivot column whose row uses it
+	for col := range columns {
+		if pivotColumns&(1<<col) != 0 {
+			continue
+		}
+		v := uint64(1) << col
+		for i, pivot := range pivots {
+			if rows[i]&(1<<col) != 0 {
+				v |= 1 << pivot
+			}
+		}
+		s.NullSpace = append(s.NullSpace, v)
+	}
+	return s, nil
+}
+
+// All iterates over every solution, 2^len(NullSpace) of them
+func (s GF2Solution) All() iter.Seq[uint64] {
+	return func(yield func(uint64) bool) {
+		x := s.Particular
+		if !yield(x) {
+			return
+		}
+		// walk the combinations in gray code order, so each step only flips one null space vector
+		for i := uint64(1); i < 1<<len(s.NullSpace); i++ {
+			x ^= s.NullSpace[bits.TrailingZeros64(i)]
+			if !yield(x) {
+				return
+			}
+		}
+	}
+}
+
+// MinWeight returns the solution with the fewest 1s, and the lowest value when there's a tie
+func (s GF2Solution) MinWeight() uint64 {
+	best := s.Particular
+	for x := range s.All() {
+		if w, bw := bits.OnesCount64(x), bits.OnesCount64(best); w < bw || (w == bw && x < best) {
+			best = x
+		}
+	}
+	return best
+}
@@ -0,0 +1,100 @@
+package linalg_test
+
+import (
+	"errors"
+	"math/bits"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/sirgwain/advent-of-code-2025/advent/linalg"
+)
+
+// multiply computes Ax over GF(2)
+func multiply(columns []uint64, x uint64) uint64 {
+	var b uint64
+	for j, c := range columns {
+		if x&(1<<j) != 0 {
+			b ^= c
+		}
+	}
+	return b
+}
+
+func TestSolveGF2(t *testing.T) {
+	tests := []struct {
+		name          string
+		columns       []uint64
+		b             uint64
+		wantNullity   int
+		wantMinWeight int
+		wantErr       error
+	}{
+		// the first machine from day 10, [.##.] (3) (1,3) (2) (2,3) (0,2) (0,1)
+		{"day10", []uint64{0b1000, 0b1010, 0b0100, 0b1100, 0b0101, 0b0011}, 0b0110, 2, 2, nil},
+		{"identity", []uint64{0b001, 0b010, 0b100}, 0b101, 0, 2, nil},
+		{"zero", []uint64{0b011, 0b110}, 0, 0, 0, nil},
+		{"duplicate columns", []uint64{0b11, 0b11, 0b11}, 0b11, 2, 1, nil},
+		{"inconsistent", []uint64{0b011, 0b110}, 0b001, 0, 0, linalg.ErrNoSolution},
+		{"no columns", nil, 0b1, 0, 0, linalg.ErrNoSolution},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := linalg.SolveGF2(tt.columns, tt.b)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SolveGF2() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if len(s.NullSpace) != tt.wantNullity {
+				t.Errorf("SolveGF2() null space = %b, want %d vectors", s.NullSpace, tt.wantNullity)
+			}
+			for x := range s.All() {
+				if got := multiply(tt.columns, x); got != tt.b {
+					t.Errorf("solution %b gives %b, want %b", x, got, tt.b)
+				}
+			}
+			if got := bits.OnesCount64(s.MinWeight()); got != tt.wantMinWeight {
+				t.Errorf("MinWeight() = %b, want %d ones", s.MinWeight(), tt.wantMinWeight)
+			}
+		})
+	}
+}
+
+func TestSolveGF2_Random(t *testing.T) {
+	// compare against trying every x on small random systems
+	r := rand.New(rand.NewPCG(1, 2))
+	for range 200 {
+		columns := make([]uint64, 1+r.IntN(10))
+		for j := range columns {
+			columns[j] = r.Uint64N(1 << 6)
+		}
+		b := r.Uint64N(1 << 6)
+
+		var want []uint64
+		for x := range uint64(1) << len(columns) {
+			if multiply(columns, x) == b {
+				want = append(want, x)
+			}
+		}
+
+		s, err := linalg.SolveGF2(columns, b)
+		if (err != nil) != (len(want) == 0) {
+			t.Fatalf("SolveGF2(%b, %b) error = %v, want %d solutions", columns, b, err, len(want))
+		}
+		if err != nil {
+			continue
+		}
+		got := slices.Sorted(s.All())
+		if !slices.Equal(got, want) {
+			t.Fatalf("SolveGF2(%b, %b) solutions = %b, want %b", columns, b, got, want)
+		}
+	}
+}
+
+func TestSolveGF2_TooManyColumns(t *testing.T) {
+	if _, err := linalg.SolveGF2(make([]uint64, 65), 0); err == nil {
+		t.Errorf("SolveGF2() with 65 columns didn't fail")
+	}
+}
@@ -0,0 +1,355 @@
+package linalg
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"math/big"
+	"slices"
+)
+
+// equation is a row of the reduced system in integers, pivot*x[col] + sum(free[k]*x[freeCols[k]]) = rhs
+type equation struct {
+	col   int
+	pivot int64
+	free  []int64
+	rhs   int64
+}
+
+// MinSumSolution finds the non-negative integer x with the smallest sum where Ax = b. Column j of A is columns[j],
+// and everything in A and b has to be non-negative, which keeps the search bounded: no x[j] can be more than the
+// smallest b[i] it adds to. It returns an error wrapping ErrNoSolution if there's no such x.
+//
+// It reduces the system with exact rational Gaussian elimination, so each pivot variable is fixed by the free
+// variables, then searches the free variables with branch and bound. The search is exponential in the number of
+// free variables, which is fine for the handful a puzzle has but not for a system that's mostly unconstrained.
+func MinSumSolution(columns [][]int, b []int) ([]int, error) {
+	n, m := len(columns), len(b)
+	for j, c := range columns {
+		if len(c) != m {
+			return nil, fmt.Errorf("column %d has %d rows, want %d", j, len(c), m)
+		}
+		for _, v := range c {
+			if v < 0 {
+				return nil, fmt.Errorf("column %d has a negative value %d", j, v)
+			}
+		}
+	}
+	for _, v := range b {
+		if v < 0 {
+			return nil, fmt.Errorf("b has a negative value %d", v)
+		}
+	}
+
+	// the most each variable could be without overshooting b
+	bounds := make([]int64, n)
+	for j, c := range columns {
+		bounds[j] = math.MaxInt64
+		for i, v := range c {
+			if v > 0 {
+				bounds[j] = min(bounds[j], int64(b[i]/v))
+			}
+		}
+		if bounds[j] == math.MaxInt64 {
+			// a column of zeros never helps
+			bounds[j] = 0
+		}
+	}
+
+	equations, freeCols, err := reduce(columns, b)
+	if err != nil {
+		return nil, err
+	}
+
+	s := newMinSumSearch(equations, freeCols, bounds)
+	s.search(0)
+	if s.best == nil {
+		return nil, ErrNoSolution
+	}
+	return s.best, nil
+}
+
+// reduce puts the augmented matrix in reduced row echelon form with exact rationals, then scales each row
+// to integers. It returns the rows and the free columns that aren't a pivot of any row.
+func reduce(columns [][]int, b []int) ([]equation, []int, error) {
+	n, m := len(columns), len(b)
+	rows := make([][]*big.Rat, m)
+	for i := range rows {
+		rows[i] = make([]*big.Rat, n+1)
+		for j, c := range columns {
+			rows[i][j] = big.NewRat(int64(c[i]), 1)
+		}
+		rows[i][n] = big.NewRat(int64(b[i]), 1)
+	}
+
+	var pivotCols []int
+	isPivot := make([]bool, n)
+	r := 0
+	tmp := new(big.Rat)
+	for col := 0; col < n && r < m; col++ {
+		pivot := -1
+		for i := r; i < m; i++ {
+			if rows[i][col].Sign() != 0 {
+				pivot = i
+				break
+			}
+		}
+		if pivot == -1 {
+			continue
+		}
+		rows[r], rows[pivot] = rows[pivot], rows[r]
+
+		// scale the pivot to 1, then clear the column from every other row
+		inv := new(big.Rat).Inv(rows[r][col])
+		for j := range rows[r] {
+			rows[r][j].Mul(rows[r][j], inv)
+		}
+		for i := range rows {
+			if i == r || rows[i][col].Sign() == 0 {
+				continue
+			}
+			factor := new(big.Rat).Set(rows[i][col])
+			for j := range rows[i] {
+				rows[i][j].Sub(rows[i][j], tmp.Mul(factor, rows[r][j]))
+			}
+		}
+		pivotCols = append(pivotCols, col)
+		isPivot[col] = true
+		r++
+	}
+
+	// rows of zeros are left over, they have to equal zero too
+	for i := r; i < m; i++ {
+		if rows[i][n].Sign() != 0 {
+			return nil, nil, ErrNoSolution
+		}
+	}
+
+	var freeCols []int
+	for col := range n {
+		if !isPivot[col] {
+			freeCols = append(freeCols, col)
+		}
+	}
+
+	equations := make([]equation, len(pivotCols))
+	for i, col := range pivotCols {
+		// multiply through by the lcm of the denominators so everything is an integer
+		lcm := big.NewInt(1)
+		gcd := new(big.Int)
+		for _, v := range rows[i] {
+			d := v.Denom()
+			gcd.GCD(nil, nil, lcm, d)
+			lcm.Mul(lcm, new(big.Int).Quo(d, gcd))
+		}
+		scaled := func(v *big.Rat) (int64, error) {
+			x := new(big.Int).Mul(v.Num(), new(big.Int).Quo(lcm, v.Denom()))
+			if !x.IsInt64() {
+				return 0, fmt.Errorf("coefficient %v overflows int64", x)
+			}
+			return x.Int64(), nil
+		}
+
+		e := equation{col: col, free: make([]int64, len(freeCols))}
+		var err error
+		if e.pivot, err = scaled(rows[i][col]); err != nil {
+			return nil, nil, err
+		}
+		if e.rhs, err = scaled(rows[i][n]); err != nil {
+			return nil, nil, err
+		}
+		for k, f := range freeCols {
+			if e.free[k], err = scaled(rows[i][f]); err != nil {
+				return nil, nil, err
+			}
+		}
+		equations[i] = e
+	}
+	return equations, freeCols, nil
+}
+
+// minSumSearch is the state of the branch and bound search over the free variables
+type minSumSearch struct {
+	equations []equation
+	freeCols  []int
+	bounds    []int64 // bound for each free variable
+	pivotMax  []int64 // the most each equation's pivot variable can be
+
+	// the sum of every variable, times scale, is base + sum(weights[k]*x[freeCols[k]])
+	scale   int64
+	base    int64
+	weights []int64
+
+	// the smallest and largest each row's free part and the weighted sum can get from variable k on
+	rowMin    [][]int64
+	rowMax    [][]int64
+	weightMin []int64
+
+	values  []int64 // the free variables so far
+	partial []int64 // sum(free[k]*x) for each row so far
+	sum     int64   // weighted sum so far
+	best    []int
+	bestSum int64
+}
+
+func newMinSumSearch(equations []equation, freeCols []int, bounds []int64) *minSumSearch {
+	// branch on the variables with the fewest values first
+	order := make([]int, len(freeCols))
+	for k := range order {
+		order[k] = k
+	}
+	slices.SortStableFunc(order, func(a, b int) int { return cmp.Compare(bounds[freeCols[a]], bounds[freeCols[b]]) })
+
+	s := &minSumSearch{
+		equations: make([]equation, len(equations)),
+		freeCols:  make([]int, len(freeCols)),
+		bounds:    make([]int64, len(freeCols)),
+		pivotMax:  make([]int64, len(equations)),
+		scale:     1,
+		weights:   make([]int64, len(freeCols)),
+		rowMin:    make([][]int64, len(equations)),
+		rowMax:    make([][]int64, len(equations)),
+		weightMin: make([]int64, len(freeCols)+1),
+		values:    make([]int64, len(freeCols)),
+		partial:   make([]int64, len(equations)),
+		bestSum:   math.MaxInt64,
+	}
+	for k, o := range order {
+		s.freeCols[k] = freeCols[o]
+		s.bounds[k] = bounds[freeCols[o]]
+	}
+	for i, e := range equations {
+		free := make([]int64, len(order))
+		for k, o := range order {
+			free[k] = e.free[o]
+		}
+		e.free = free
+		s.equations[i] = e
+		s.pivotMax[i] = e.pivot * bounds[e.col]
+	}
+
+	// each pivot variable is (rhs - free part) / pivot, so scale everything by the pivots' lcm to stay in integers
+	for _, e := range s.equations {
+		s.scale = lcm(s.scale, e.pivot)
+	}
+	for _, e := range s.equations {
+		s.base += s.scale / e.pivot * e.rhs
+	}
+	for k := range s.freeCols {
+		s.weights[k] = s.scale
+		for _, e := range s.equations {
+			s.weights[k] -= s.scale / e.pivot * e.free[k]
+		}
+	}
+
+	for i, e := range s.equations {
+		s.rowMin[i] = make([]int64, len(s.freeCols)+1)
+		s.rowMax[i] = make([]int64, len(s.freeCols)+1)
+		for k := len(s.freeCols) - 1; k >= 0; k-- {
+			s.rowMin[i][k] = s.rowMin[i][k+1] + min(0, e.free[k]*s.bounds[k])
+			s.rowMax[i][k] = s.rowMax[i][k+1] + max(0, e.free[k]*s.bounds[k])
+		}
+	}
+	for k := len(s.freeCols) - 1; k >= 0; k-- {
+		s.weightMin[k] = s.weightMin[k+1] + min(0, s.weights[k]*s.bounds[k])
+	}
+	return s
+}
+
+// limit narrows lo <= x <= hi to the values where c*x <= most. It returns false if there aren't any.
+func limit(lo, hi *int64, c, most int64) bool {
+	switch {
+	case c > 0:
+		*hi = min(*hi, floorDiv(most, c))
+	case c < 0:
+		*lo = max(*lo, ceilDiv(most, c))
+	case most < 0:
+		return false
+	}
+	return *lo <= *hi
+}
+
+// search tries every value of free variable k that could still beat the best so far
+func (s *minSumSearch) search(k int) {
+	if k == len(s.freeCols) {
+		s.check()
+		return
+	}
+
+	// narrow down variable k assuming the ones after it could be anything in their bounds
+	lo, hi := int64(0), s.bounds[k]
+	for i, e := range s.equations {
+		rest := e.rhs - s.partial[i]
+		c := e.free[k]
+		// the pivot variable can't go negative, so the free part can't be more than rest
+		if !limit(&lo, &hi, c, rest-s.rowMin[i][k+1]) {
+			return
+		}
+		// or go over its bound, so the free part has to be at least rest - pivotMax
+		if !limit(&lo, &hi, -c, s.pivotMax[i]-rest+s.rowMax[i][k+1]) {
+			return
+		}
+	}
+	// the sum is an integer, so it has to come in under best-1 to beat it
+	if s.best != nil {
+		if !limit(&lo, &hi, s.weights[k], (s.bestSum-1)*s.scale-s.base-s.sum-s.weightMin[k+1]) {
+			return
+		}
+	}
+
+	for v := lo; v <= hi; v++ {
+		s.values[k] = v
+		for i, e := range s.equations {
+			s.partial[i] += e.free[k] * v
+		}
+		s.sum += s.weights[k] * v
+
+		s.search(k + 1)
+
+		for i, e := range s.equations {
+			s.partial[i] -= e.free[k] * v
+		}
+		s.sum -= s.weights[k] * v
+	}
+}
+
+// check records the solution if every pivot variable comes out a non-negative integer
+func (s *minSumSearch) check() {
+	x := make([]int, len(s.freeCols)+len(s.equations))
+	var total int64
+	for i, e := range s.equations {
+		rest := e.rhs - s.partial[i]
+		if rest < 0 || rest%e.pivot != 0 {
+			return
+		}
+		x[e.col] = int(rest / e.pivot)
+		total += rest / e.pivot
+	}
+	for k, f := range s.freeCols {
+		x[f] = int(s.values[k])
+		total += s.values[k]
+	}
+	if total < s.bestSum {
+		s.best, s.bestSum = x, total
+	}
+}
+
+func lcm(a, b int64) int64 {
+	x, y := a, b
+	for y != 0 {
+		x, y = y, x%y
+	}
+	return a / x * b
+}
+
+func floorDiv(a, b int64) int64 {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
+
+func ceilDiv(a, b int64) int64 {
+	return -floorDiv(-a, b)
+}
@@ -0,0 +1,122 @@
+package linalg_test
+
+import (
+	"errors"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/sirgwain/advent-of-code-2025/advent/linalg"
+)
+
+// apply computes Ax
+func apply(columns [][]int, x []int, rows int) []int {
+	b := make([]int, rows)
+	for j, c := range columns {
+		for i, v := range c {
+			b[i] += v * x[j]
+		}
+	}
+	return b
+}
+
+func sum(x []int) int {
+	total := 0
+	for _, v := range x {
+		total += v
+	}
+	return total
+}
+
+func TestMinSumSolution(t *testing.T) {
+	tests := []struct {
+		name    string
+		columns [][]int
+		b       []int
+		wantSum int
+		wantErr error
+	}{
+		// the machines from day 10
+		{"day10 1", [][]int{{0, 0, 0, 1}, {0, 1, 0, 1}, {0, 0, 1, 0}, {0, 0, 1, 1}, {1, 0, 1, 0}, {1, 1, 0, 0}}, []int{3, 5, 4, 7}, 10, nil},
+		{"day10 2", [][]int{{1, 0, 1, 1, 1}, {0, 0, 1, 1, 0}, {1, 0, 0, 0, 1}, {1, 1, 1, 0, 0}, {0, 1, 1, 1, 1}}, []int{7, 5, 12, 7, 2}, 12, nil},
+		{"day10 3", [][]int{{1, 1, 1, 1, 1, 0}, {1, 0, 0, 1, 1, 0}, {1, 1, 1, 0, 1, 1}, {0, 1, 1, 0, 0, 0}}, []int{10, 11, 11, 5, 10, 5}, 11, nil},
+		{"rational pivots", [][]int{{2, 1}, {1, 2}}, []int{5, 4}, 3, nil},
+		{"zero column", [][]int{{0, 0}, {1, 1}}, []int{3, 3}, 3, nil},
+		{"nothing to do", [][]int{{1, 1}}, []int{0, 0}, 0, nil},
+		{"not an integer", [][]int{{2}}, []int{3}, 0, linalg.ErrNoSolution},
+		{"inconsistent", [][]int{{1, 1}, {1, 1}}, []int{1, 2}, 0, linalg.ErrNoSolution},
+		{"needs negative", [][]int{{1, 0}, {1, 1}}, []int{1, 2}, 0, linalg.ErrNoSolution},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x, err := linalg.MinSumSolution(tt.columns, tt.b)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("MinSumSolution() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := apply(tt.columns, x, len(tt.b)); !slices.Equal(got, tt.b) {
+				t.Errorf("MinSumSolution() = %v gives %v, want %v", x, got, tt.b)
+			}
+			if got := sum(x); got != tt.wantSum {
+				t.Errorf("MinSumSolution() = %v sums to %d, want %d", x, got, tt.wantSum)
+			}
+		})
+	}
+}
+
+func TestMinSumSolution_Random(t *testing.T) {
+	// compare against trying every x on small random systems
+	r := rand.New(rand.NewPCG(3, 4))
+	for range 300 {
+		rows, n := 1+r.IntN(4), 1+r.IntN(4)
+		columns := make([][]int, n)
+		for j := range columns {
+			columns[j] = make([]int, rows)
+			for i := range columns[j] {
+				columns[j][i] = r.IntN(3)
+			}
+		}
+		// usually make b from some x so there's a solution
+		b := make([]int, rows)
+		if r.IntN(4) == 0 {
+			for i := range b {
+				b[i] = r.IntN(8)
+			}
+		} else {
+			x := make([]int, n)
+			for j := range x {
+				x[j] = r.IntN(4)
+			}
+			b = apply(columns, x, rows)
+		}
+
+		want := -1
+		x := make([]int, n)
+		var try func(j int)
+		try = func(j int) {
+			if j == n {
+				if slices.Equal(apply(columns, x, rows), b) && (want == -1 || sum(x) < want) {
+					want = sum(x)
+				}
+				return
+			}
+			for v := range 16 {
+				x[j] = v
+				try(j + 1)
+			}
+		}
+		try(0)
+
+		got, err := linalg.MinSumSolution(columns, b)
+		switch {
+		case want == -1 && !errors.Is(err, linalg.ErrNoSolution):
+			t.Fatalf("MinSumSolution(%v, %v) = %v, %v, want no solution", columns, b, got, err)
+		case want != -1 && err != nil:
+			t.Fatalf("MinSumSolution(%v, %v) error = %v, want sum %d", columns, b, err, want)
+		case want != -1 && (sum(got) != want || !slices.Equal(apply(columns, got, rows), b)):
+			t.Fatalf("MinSumSolution(%v, %v) = %v, want sum %d", columns, b, got, want)
+		}
+	}
+}
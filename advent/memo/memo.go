@@ -0,0 +1,162 @@
+// Package memo caches the results of expensive recursive functions by key
+package memo
+
+import (
+	"container/list"
+	"fmt"
+)
+
+// Cache maps keys to computed values. Keys are any comparable value, so structs and fixed size arrays work
+// directly instead of formatting everything into strings. A cache with a max size evicts the least recently
+// used value when it's full.
+type Cache[K comparable, V any] struct {
+	values  map[K]V
+	maxSize int
+	stats   Stats
+
+	// only kept when there's a max size, the most recently used key is at the front
+	recent   *list.List
+	elements map[K]*list.Element
+}
+
+// Stats counts how well a cache is doing
+type Stats struct {
+	Hits      int
+	Misses    int
+	Evictions int
+}
+
+// HitRate is the fraction of lookups that found a value
+func (s Stats) HitRate() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+func (s Stats) String() string {
+	return fmt.Sprintf("%d hits, %d misses, %d evictions (%.1f%% hit rate)", s.Hits, s.Misses, s.Evictions, s.HitRate()*100)
+}
+
+type options struct {
+	maxSize  int
+	capacity int
+}
+
+// Option configures a Cache
+type Option func(o *options)
+
+// WithMaxSize limits the cache to n values. Zero, the default, is unlimited.
+func WithMaxSize(n int) Option {
+	return func(o *options) {
+		o.maxSize = n
+	}
+}
+
+// WithCapacity preallocates room for n values
+func WithCapacity(n int) Option {
+	return func(o *options) {
+		o.capacity = n
+	}
+}
+
+// New makes an empty cache
+func New[K comparable, V any](opts ...Option) *Cache[K, V] {
+	o := options{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	c := &Cache[K, V]{maxSize: o.maxSize}
+	capacity := o.capacity
+	if c.maxSize > 0 {
+		capacity = min(capacity, c.maxSize)
+		c.recent = list.New()
+		c.elements = make(map[K]*list.Element, capacity)
+	}
+	c.values = make(map[K]V, capacity)
+	return c
+}
+
+// Get looks up the value for key and counts a hit or a miss
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	v, ok := c.values[key]
+	if !ok {
+		c.stats.Misses++
+		return v, false
+	}
+	c.stats.Hits++
+	c.touch(key)
+	return v, true
+}
+
+// Set stores the value for key, evicting the least recently used value if the cache is full
+func (c *Cache[K, V]) Set(key K, value V) {
+	if _, ok := c.values[key]; !ok && c.recent != nil {
+		if c.recent.Len() >= c.maxSize {
+			oldest := c.recent.Remove(c.recent.Back()).(K)
+			delete(c.values, oldest)
+			delete(c.elements, oldest)
+			c.stats.Evictions++
+		}
+		c.elements[key] = c.recent.PushFront(key)
+	}
+	c.values[key] = value
+	c.touch(key)
+}
+
+// touch marks key as the most recently used
+func (c *Cache[K, V]) touch(key K) {
+	if c.recent != nil {
+		c.recent.MoveToFront(c.elements[key])
+	}
+}
+
+// Do returns the cached value for key, or calls compute and caches its result. compute can call Do
+// again for other keys, which is how recursive functions are memoized.
+func (c *Cache[K, V]) Do(key K, compute func() V) V {
+	if v, ok := c.Get(key); ok {
+		return v
+	}
+	v := compute()
+	c.Set(key, v)
+	return v
+}
+
+// Len is the number of cached values
+func (c *Cache[K, V]) Len() int {
+	return len(c.values)
+}
+
+// Stats returns the hits, misses and evictions so far
+func (c *Cache[K, V]) Stats() Stats {
+	return c.stats
+}
+
+// Reset empties the cache and its stats
+func (c *Cache[K, V]) Reset() {
+	clear(c.values)
+	if c.recent != nil {
+		c.recent.Init()
+		clear(c.elements)
+	}
+	c.stats = Stats{}
+}
+
+// Array is a fixed size array of T, which unlike a slice can be used as a key
+type Array[T comparable] interface {
+	~[4]T | ~[8]T | ~[16]T | ~[32]T | ~[64]T
+}
+
+// ArrayKey copies v into a fixed size array so it can be used as a key. It returns false if v doesn't fit.
+// The rest of the array is left as zeros, so only use it for slices that are all the same length.
+func ArrayKey[A Array[T], T comparable](v []T) (A, bool) {
+	var key A
+	if len(v) > len(key) {
+		return key, false
+	}
+	for i, x := range v {
+		key[i] = x
+	}
+	return key, true
+}
@@ -0,0 +1,85 @@
+package memo_test
+
+import (
+	"testing"
+
+	"github.com/sirgwain/advent-of-code-2025/advent/memo"
+)
+
+func TestCache_Do(t *testing.T) {
+	// a memoized fibonacci only computes each number once
+	cache := memo.New[int, int]()
+	calls := 0
+	var fib func(n int) int
+	fib = func(n int) int {
+		return cache.Do(n, func() int {
+			calls++
+			if n < 2 {
+				return n
+			}
+			return fib(n-1) + fib(n-2)
+		})
+	}
+
+	if got := fib(80); got != 23416728348467685 {
+		t.Errorf("fib(80) = %d, want 23416728348467685", got)
+	}
+	if calls != 81 || cache.Len() != 81 {
+		t.Errorf("fib(80) computed %d values, cached %d, want 81, 81", calls, cache.Len())
+	}
+	if got, want := cache.Stats(), (memo.Stats{Hits: 78, Misses: 81}); got != want {
+		t.Errorf("Stats() = %v, want %v", got, want)
+	}
+
+	cache.Reset()
+	if cache.Len() != 0 || cache.Stats() != (memo.Stats{}) {
+		t.Errorf("Reset() left %d values, %v", cache.Len(), cache.Stats())
+	}
+}
+
+func TestCache_MaxSize(t *testing.T) {
+	cache := memo.New[string, int](memo.WithMaxSize(2))
+	cache.Set("a", 1)
+	cache.Set("b", 2)
+	cache.Get("a") // b is now the least recently used
+	cache.Set("c", 3)
+
+	tests := []struct {
+		key    string
+		want   int
+		wantOk bool
+	}{
+		{"a", 1, true},
+		{"b", 0, false},
+		{"c", 3, true},
+	}
+	for _, tt := range tests {
+		if got, ok := cache.Get(tt.key); got != tt.want || ok != tt.wantOk {
+			t.Errorf("Get(%s) = %d, %v, want %d, %v", tt.key, got, ok, tt.want, tt.wantOk)
+		}
+	}
+
+	// updating a value doesn't evict anything
+	cache.Set("c", 4)
+	if got := cache.Stats(); cache.Len() != 2 || got.Evictions != 1 {
+		t.Errorf("Set() left %d values with %d evictions, want 2, 1", cache.Len(), got.Evictions)
+	}
+}
+
+func TestArrayKey(t *testing.T) {
+	cache := memo.New[[4]int, string]()
+	key, ok := memo.ArrayKey[[4]int]([]int{1, 2, 3})
+	if !ok {
+		t.Fatalf("ArrayKey() didn't fit 3 values in 4")
+	}
+	cache.Set(key, "found")
+
+	// a different slice with the same values is the same key
+	same, _ := memo.ArrayKey[[4]int]([]int{1, 2, 3})
+	if got, _ := cache.Get(same); got != "found" {
+		t.Errorf("Get(%v) = %q, want found", same, got)
+	}
+	if _, ok := memo.ArrayKey[[4]int]([]int{1, 2, 3, 4, 5}); ok {
+		t.Errorf("ArrayKey() fit 5 values in 4")
+	}
+}
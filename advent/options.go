@@ -4,6 +4,14 @@ package advent
 type Options struct {
 	Delay int
 	Quiet bool
+	Theme Theme
+	Copy  bool
+
+	// Params are day specific settings, like which nodes to search between
+	Params map[string]string
+
+	// InputName is where the input came from, like its filename, for errors about it
+	InputName string
 }
 
 // Option is a functional option type that modifies the Options.
@@ -23,11 +31,48 @@ func WithQuiet(quiet bool) Option {
 	}
 }
 
+// WithTheme sets the Theme days render with.
+func WithTheme(theme Theme) Option {
+	return func(o *Options) {
+		o.Theme = theme
+	}
+}
+
+// WithCopy copies the final answers to the clipboard.
+func WithCopy(copy bool) Option {
+	return func(o *Options) {
+		o.Copy = copy
+	}
+}
+
+// WithParams sets day specific params.
+func WithParams(params map[string]string) Option {
+	return func(o *Options) {
+		o.Params = params
+	}
+}
+
+// WithInputName sets the name errors use for the input.
+func WithInputName(name string) Option {
+	return func(o *Options) {
+		o.InputName = name
+	}
+}
+
+// Param returns the param for key, or def if it wasn't set.
+func (o *Options) Param(key, def string) string {
+	if v, ok := o.Params[key]; ok {
+		return v
+	}
+	return def
+}
+
 func NewRun(opts ...Option) *Options {
 	// Default options
 	options := &Options{
 		Delay: 0,
 		Quiet: false,
+		Theme: themes[ThemeDark],
 	}
 
 	// Apply provided options
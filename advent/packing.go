@@ -0,0 +1,186 @@
+package advent
+
+import "slices"
+
+// Placement is one piece put on a board
+type Placement struct {
+	Piece int     // index of the piece in the list passed to Pack
+	Cells []Point // the board cells it covers
+}
+
+// orientation is a piece turned and flipped one way, as offsets from its first cell in row-major order
+type orientation []Point
+
+// orientations returns the distinct ways to turn and flip a piece, anchored on their first cells
+func orientations(piece Polyomino) []orientation {
+	if piece.Len() == 0 {
+		return nil
+	}
+	var all []orientation
+	for _, shape := range piece.Orientations() {
+		cells := shape.Cells()
+		o := make(orientation, len(cells))
+		for i, c := range cells {
+			o[i] = c.Sub(cells[0])
+		}
+		all = append(all, o)
+	}
+	return all
+}
+
+// packer is the state of a Pack search
+type packer struct {
+	width, height int
+	filled        []bool // cells covered or given up as empty
+	shapes        [][]orientation
+	remaining     []int
+	left          int // pieces left to place
+	slack         int // cells that can still be left empty
+	placements    []Placement
+}
+
+// Pack places counts[i] copies of pieces[i] on a width x height board without overlapping. Pieces can be turned
+// and flipped, and the board doesn't have to be full. It returns where each piece went, or false if they don't fit
+// or a count is negative.
+//
+// It walks the board cell by cell, either covering the first open cell with a piece or leaving it empty. Every
+// piece has to start at some first cell, so this finds every packing, and there are only so many cells that can
+// be left empty before the pieces can't fit in what's left.
+func Pack(width, height int, pieces []Polyomino, counts []int) ([]Placement, bool) {
+	p := &packer{
+		width:     width,
+		height:    height,
+		filled:    make([]bool, width*height),
+		shapes:    make([][]orientation, len(pieces)),
+		remaining: slices.Clone(counts),
+		slack:     width * height,
+	}
+	for i, piece := range pieces {
+		p.shapes[i] = orientations(piece)
+		p.left += counts[i]
+		p.slack -= counts[i] * piece.Len()
+		if counts[i] < 0 {
+			// there's no packing that leaves out pieces
+			return nil, false
+		}
+		if counts[i] > 0 && len(p.shapes[i]) == 0 {
+			// an empty piece takes up no room, but it can't be placed anywhere either
+			return nil, false
+		}
+	}
+	if p.slack < 0 {
+		return nil, false
+	}
+	if placements, ok := packBlocks(width, height, pieces, counts); ok {
+		return placements, true
+	}
+	if !p.search(0) {
+		return nil, false
+	}
+	return p.placements, true
+}
+
+// packBlocks handles boards with plenty of room, giving every piece its own box the size of the largest piece
+func packBlocks(width, height int, pieces []Polyomino, counts []int) ([]Placement, bool) {
+	boxWidth, boxHeight, total := 1, 1, 0
+	for i, piece := range pieces {
+		if counts[i] > 0 {
+			boxWidth, boxHeight = max(boxWidth, piece.Width()), max(boxHeight, piece.Height())
+			total += counts[i]
+		}
+	}
+	across := width / boxWidth
+	if across*(height/boxHeight) < total {
+		return nil, false
+	}
+
+	var placements []Placement
+	for i, piece := range pieces {
+		for range counts[i] {
+			box := len(placements)
+			corner := Point{X: box % across * boxWidth, Y: box / across * boxHeight}
+			placement := Placement{Piece: i, Cells: piece.Cells()}
+			for j, c := range placement.Cells {
+				placement.Cells[j] = corner.Add(c)
+			}
+			placements = append(placements, placement)
+		}
+	}
+	return placements, true
+}
+
+// search fills the board from cell on
+func (p *packer) search(cell int) bool {
+	if p.left == 0 {
+		return true
+	}
+	for cell < len(p.filled) && p.filled[cell] {
+		cell++
+	}
+	if cell == len(p.filled) {
+		return false
+	}
+	at := Point{X: cell % p.width, Y: cell / p.width}
+
+	for piece, shapes := range p.shapes {
+		if p.remaining[piece] == 0 {
+			continue
+		}
+		for _, o := range shapes {
+			if !p.fits(at, o) {
+				continue
+			}
+			p.place(at, o, true)
+			p.remaining[piece]--
+			p.left--
+			p.placements = append(p.placements, Placement{Piece: piece, Cells: p.cells(at, o)})
+
+			if p.search(cell + 1) {
+				return true
+			}
+
+			p.placements = p.placements[:len(p.placements)-1]
+			p.left++
+			p.remaining[piece]++
+			p.place(at, o, false)
+		}
+	}
+
+	// leave this cell empty, if there's room to spare
+	if p.slack == 0 {
+		return false
+	}
+	p.slack--
+	p.filled[cell] = true
+	if p.search(cell + 1) {
+		return true
+	}
+	p.filled[cell] = false
+	p.slack++
+	return false
+}
+
+func (p *packer) fits(at Point, o orientation) bool {
+	for _, offset := range o {
+		c := at.Add(offset)
+		if c.X < 0 || c.X >= p.width || c.Y >= p.height || p.filled[c.Y*p.width+c.X] {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *packer) place(at Point, o orientation, filled bool) {
+	for _, offset := range o {
+		c := at.Add(offset)
+		p.filled[c.Y*p.width+c.X] = filled
+	}
+}
+
+func (p *packer) cells(at Point, o orientation) []Point {
+	cells := make([]Point, len(o))
+	for i, offset := range o {
+		cells[i] = at.Add(offset)
+	}
+	return cells
+}
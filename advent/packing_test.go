@@ -0,0 +1,66 @@
+package advent
+
+import "testing"
+
+func parsePiece(t *testing.T, lines ...string) Polyomino {
+	p, err := ParsePolyomino(lines)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestPack(t *testing.T) {
+	plus := parsePiece(t, ".#.", "###", ".#.")
+	domino := parsePiece(t, "##")
+	l := parsePiece(t, "#.", "#.", "##")
+	tests := []struct {
+		name          string
+		width, height int
+		pieces        []Polyomino
+		counts        []int
+		want          bool
+	}{
+		{"exact", 4, 2, []Polyomino{l}, []int{2}, true},
+		{"turned and flipped", 4, 4, []Polyomino{l}, []int{4}, true},
+		{"fill with dominoes", 5, 4, []Polyomino{domino}, []int{10}, true},
+		{"too big", 3, 3, []Polyomino{l}, []int{3}, false},
+		{"area fits but shape doesn't", 3, 3, []Polyomino{plus, domino}, []int{1, 1}, false},
+		{"nothing to place", 1, 1, []Polyomino{plus}, []int{0}, true},
+		{"negative count", 3, 3, []Polyomino{domino}, []int{-2}, false},
+		{"negative count with room to spare", 30, 30, []Polyomino{plus, domino}, []int{1, -1}, false},
+		{"plenty of room", 30, 30, []Polyomino{plus, l}, []int{20, 30}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			placements, ok := Pack(tt.width, tt.height, tt.pieces, tt.counts)
+			if ok != tt.want {
+				t.Fatalf("Pack() = %v, want %v", ok, tt.want)
+			}
+			if !ok {
+				return
+			}
+
+			// every piece is on the board once, without overlapping
+			board := NewGrid[bool](tt.width, tt.height)
+			placed := make([]int, len(tt.pieces))
+			for _, placement := range placements {
+				placed[placement.Piece]++
+				if len(placement.Cells) != tt.pieces[placement.Piece].Len() {
+					t.Errorf("placement %v has the wrong number of cells", placement)
+				}
+				for _, c := range placement.Cells {
+					if !board.InBounds(c) || board.At(c) {
+						t.Fatalf("placement %v is off the board or overlaps", placement)
+					}
+					board.Set(c, true)
+				}
+			}
+			for i, count := range tt.counts {
+				if placed[i] != count {
+					t.Errorf("Pack() placed %d of piece %d, want %d", placed[i], i, count)
+				}
+			}
+		})
+	}
+}
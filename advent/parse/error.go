@@ -0,0 +1,54 @@
+package parse
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Error is a problem with a line of input
+type Error struct {
+	File   string
+	Line   int    // 1-based, 0 if the error isn't from a line
+	Column int    // 1-based, 0 if the error is about the whole line
+	Text   string // the whole line
+	Token  string // the text that couldn't be parsed, if there is one
+	Err    error
+}
+
+func (e *Error) Error() string {
+	var sb strings.Builder
+	if e.File != "" {
+		sb.WriteString(e.File)
+		sb.WriteString(":")
+	}
+	if e.Line > 0 {
+		fmt.Fprintf(&sb, "%d:", e.Line)
+		if e.Column > 0 {
+			fmt.Fprintf(&sb, "%d:", e.Column)
+		}
+	}
+	if sb.Len() > 0 {
+		sb.WriteString(" ")
+	}
+	sb.WriteString(e.Err.Error())
+	if e.Token != "" {
+		fmt.Fprintf(&sb, ": %q", e.Token)
+	}
+	return sb.String()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// at sets where an error from Format.Parse came from
+func (e *Error) at(line Line) *Error {
+	e.File, e.Line = line.File, line.Num
+	return e
+}
+
+// column converts a byte offset into a line to a 1-based column
+func column(text string, offset int) int {
+	return utf8.RuneCountInString(text[:offset]) + 1
+}
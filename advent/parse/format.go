@@ -0,0 +1,366 @@
+package parse
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+var textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+
+// Format is a compiled pattern for parsing lines into a T.
+//
+// A pattern is literal text with {Name} placeholders for the fields of T, so "{Low}-{High}" parses "3-5" into a
+// struct with Low and High fields. A field takes everything up to the text after it in the pattern, so fields
+// need some text between them, except for single character fields. {} is T itself, for records that are a
+// single value.
+//
+// Fields can be strings, numbers, bools, or anything with an UnmarshalText method. Numbers are always parsed as
+// numbers, even bytes and runes. {Dir:c} takes a single character instead, as in "{Dir:c}{Clicks}" for "L68", and
+// works for bytes, runes, strings and UnmarshalText fields. Slices need a separator after a colon, {Joltage:,}
+// splits on commas, {Outs: } splits on runs of spaces and {Digits:} splits into single characters. Use {{ and }}
+// for literal braces.
+type Format[T any] struct {
+	pattern  string
+	literals []string // the text around the fields, one more than there are fields
+	fields   []formatField
+}
+
+type formatField struct {
+	name  string // empty for T itself
+	index []int  // path to the struct field, nil for T itself
+	typ   reflect.Type
+	slice bool
+	sep   string
+	char  bool // takes a single character
+}
+
+// Compile checks a pattern against T
+func Compile[T any](pattern string) (*Format[T], error) {
+	f := &Format[T]{pattern: pattern}
+	typ := reflect.TypeFor[T]()
+
+	var literal strings.Builder
+	for i := 0; i < len(pattern); {
+		switch {
+		case strings.HasPrefix(pattern[i:], "{{"):
+			literal.WriteByte('{')
+			i += 2
+		case strings.HasPrefix(pattern[i:], "}}"):
+			literal.WriteByte('}')
+			i += 2
+		case pattern[i] == '{':
+			end := strings.IndexByte(pattern[i:], '}')
+			if end == -1 {
+				return nil, fmt.Errorf("pattern %q has an unclosed {", pattern)
+			}
+			field, err := compileField(typ, pattern[i+1:i+end])
+			if err != nil {
+				return nil, fmt.Errorf("pattern %q: %w", pattern, err)
+			}
+			if len(f.fields) > 0 && literal.Len() == 0 && !f.fields[len(f.fields)-1].char {
+				return nil, fmt.Errorf("pattern %q needs text between %s and %s", pattern, f.fields[len(f.fields)-1], field)
+			}
+			f.literals = append(f.literals, literal.String())
+			f.fields = append(f.fields, field)
+			literal.Reset()
+			i += end + 1
+		case pattern[i] == '}':
+			return nil, fmt.Errorf("pattern %q has a } without a {, use }} for a literal }", pattern)
+		default:
+			literal.WriteByte(pattern[i])
+			i++
+		}
+	}
+	f.literals = append(f.literals, literal.String())
+	return f, nil
+}
+
+// MustCompile is Compile for patterns that are known to be good, it panics if they aren't
+func MustCompile[T any](pattern string) *Format[T] {
+	f, err := Compile[T](pattern)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+func compileField(typ reflect.Type, spec string) (formatField, error) {
+	name, sep, hasSep := strings.Cut(spec, ":")
+	field := formatField{name: name, typ: typ, sep: sep}
+	if name != "" {
+		if typ.Kind() != reflect.Struct {
+			return field, fmt.Errorf("{%s} needs a struct, %s isn't one, use {}", name, typ)
+		}
+		sf, ok := typ.FieldByName(name)
+		if !ok || !sf.IsExported() {
+			return field, fmt.Errorf("%s has no exported field %s", typ, name)
+		}
+		field.index, field.typ = sf.Index, sf.Type
+	}
+
+	field.slice = field.typ.Kind() == reflect.Slice && !isUnmarshaler(field.typ)
+	switch {
+	case field.slice && !hasSep:
+		return field, fmt.Errorf("%s is a slice, it needs a separator like {%s:,}", field, name)
+	case !field.slice && hasSep && sep != "c":
+		return field, fmt.Errorf("%s isn't a slice, it can't have a separator, only :c for a single character", field)
+	}
+
+	scalar := field.typ
+	if field.slice {
+		scalar = field.typ.Elem()
+	}
+	if !isUnmarshaler(scalar) {
+		switch scalar.Kind() {
+		case reflect.String, reflect.Bool, reflect.Float32, reflect.Float64,
+			reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		default:
+			return field, fmt.Errorf("%s is a %s, which can't be parsed", field, scalar)
+		}
+	}
+	if !field.slice && hasSep {
+		// {Name:c}
+		switch field.typ.Kind() {
+		case reflect.Uint8, reflect.Int32, reflect.String:
+		default:
+			if !isUnmarshaler(field.typ) {
+				return field, fmt.Errorf("%s is a %s, which can't hold a single character", field, field.typ)
+			}
+		}
+		field.char = true
+	}
+	return field, nil
+}
+
+// String names the field in errors
+func (field formatField) String() string {
+	if field.name == "" {
+		return field.typ.String()
+	}
+	return field.name
+}
+
+func isUnmarshaler(typ reflect.Type) bool {
+	return reflect.PointerTo(typ).Implements(textUnmarshalerType)
+}
+
+func (f *Format[T]) String() string {
+	return f.pattern
+}
+
+// Parse parses a single line of text. Errors are an *Error with the column and text that didn't match.
+func (f *Format[T]) Parse(text string) (T, error) {
+	var record T
+	value := reflect.ValueOf(&record).Elem()
+
+	offset := 0
+	fail := func(token string, format string, args ...any) (T, error) {
+		var zero T
+		return zero, &Error{Column: column(text, offset), Text: text, Token: token, Err: fmt.Errorf(format, args...)}
+	}
+	expect := func(literal string) bool {
+		if !strings.HasPrefix(text[offset:], literal) {
+			return false
+		}
+		offset += len(literal)
+		return true
+	}
+
+	if !expect(f.literals[0]) {
+		return fail(text[offset:], "expected %q", f.literals[0])
+	}
+	for k, field := range f.fields {
+		rest := text[offset:]
+		next := f.literals[k+1]
+
+		var n int
+		switch {
+		case field.char:
+			_, n = utf8.DecodeRuneInString(rest)
+			if n == 0 {
+				return fail("", "expected a character for %s", field)
+			}
+		case next == "":
+			// the last field takes the rest of the line
+			n = len(rest)
+		default:
+			n = strings.Index(rest, next)
+			if n == -1 {
+				return fail(rest, "expected %q after %s", next, field)
+			}
+		}
+
+		target := value
+		if field.index != nil {
+			target = value.FieldByIndex(field.index)
+		}
+		if err := field.set(target, rest[:n], offset, text); err != nil {
+			return record, err
+		}
+		offset += n
+
+		if !expect(next) {
+			return fail(text[offset:], "expected %q after %s", next, field)
+		}
+	}
+	if offset < len(text) {
+		return fail(text[offset:], "unexpected text after %s", f.literals[len(f.literals)-1])
+	}
+	return record, nil
+}
+
+// set parses token into target, offset is where token starts in text for errors
+func (field formatField) set(target reflect.Value, token string, offset int, text string) error {
+	if !field.slice {
+		if err := setScalar(target, token, field.char); err != nil {
+			return field.error(text, offset, token, err)
+		}
+		return nil
+	}
+
+	parts, offsets := split(token, field.sep)
+	elems := reflect.MakeSlice(field.typ, len(parts), len(parts))
+	for i, part := range parts {
+		if err := setScalar(elems.Index(i), part, false); err != nil {
+			return field.error(text, offset+offsets[i], part, err)
+		}
+	}
+	target.Set(elems)
+	return nil
+}
+
+// error points at the token that couldn't be set. An UnmarshalText that parses with a Format of its own returns
+// an *Error, which is moved to where its text is in the line.
+func (field formatField) error(text string, offset int, token string, err error) *Error {
+	e := &Error{Column: column(text, offset), Text: text, Token: token, Err: err}
+	if inner, ok := err.(*Error); ok {
+		e.Column += max(inner.Column-1, 0)
+		e.Token, e.Err = inner.Token, inner.Err
+	}
+	if field.name != "" {
+		e.Err = fmt.Errorf("invalid %s: %w", field.name, e.Err)
+	}
+	return e
+}
+
+// errNotValid hides strconv's errors, which repeat the text that didn't parse
+func errNotValid(typ reflect.Type) error {
+	return fmt.Errorf("not a valid %s", typ)
+}
+
+func setScalar(target reflect.Value, s string, char bool) error {
+	if isUnmarshaler(target.Type()) {
+		return target.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+	}
+
+	if char {
+		r, size := utf8.DecodeRuneInString(s)
+		if size != len(s) || size == 0 {
+			return fmt.Errorf("expected a single character")
+		}
+		switch target.Kind() {
+		case reflect.Uint8:
+			if r > 0xff {
+				return fmt.Errorf("%q doesn't fit in a byte", r)
+			}
+			target.SetUint(uint64(r))
+		case reflect.Int32:
+			target.SetInt(int64(r))
+		case reflect.String:
+			target.SetString(s)
+		}
+		return nil
+	}
+
+	switch target.Kind() {
+	case reflect.String:
+		target.SetString(s)
+	case reflect.Bool:
+		v, err := strconv.ParseBool(s)
+		if err != nil {
+			return errNotValid(target.Type())
+		}
+		target.SetBool(v)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v, err := strconv.ParseInt(s, 10, target.Type().Bits())
+		if err != nil {
+			return errNotValid(target.Type())
+		}
+		target.SetInt(v)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v, err := strconv.ParseUint(s, 10, target.Type().Bits())
+		if err != nil {
+			return errNotValid(target.Type())
+		}
+		target.SetUint(v)
+	case reflect.Float32, reflect.Float64:
+		v, err := strconv.ParseFloat(s, target.Type().Bits())
+		if err != nil {
+			return errNotValid(target.Type())
+		}
+		target.SetFloat(v)
+	}
+	return nil
+}
+
+// split breaks s at sep, returning each part and where it starts. A space splits on runs of spaces and tabs,
+// ignoring them at the ends, and an empty separator splits into characters.
+func split(s, sep string) (parts []string, offsets []int) {
+	switch sep {
+	case "":
+		for i, r := range s {
+			parts = append(parts, string(r))
+			offsets = append(offsets, i)
+		}
+	case " ":
+		start := -1
+		for i := 0; i <= len(s); i++ {
+			space := i == len(s) || s[i] == ' ' || s[i] == '\t'
+			switch {
+			case space && start != -1:
+				parts = append(parts, s[start:i])
+				offsets = append(offsets, start)
+				start = -1
+			case !space && start == -1:
+				start = i
+			}
+		}
+	default:
+		if s == "" {
+			return nil, nil
+		}
+		offset := 0
+		for part := range strings.SplitSeq(s, sep) {
+			parts = append(parts, part)
+			offsets = append(offsets, offset)
+			offset += len(part) + len(sep)
+		}
+	}
+	return parts, offsets
+}
+
+// ParseLine parses a line of input, errors say which line it was
+func (f *Format[T]) ParseLine(line Line) (T, error) {
+	record, err := f.Parse(line.Text)
+	if err != nil {
+		return record, err.(*Error).at(line)
+	}
+	return record, nil
+}
+
+// Records parses every line of the input
+func (f *Format[T]) Records(in *Input) ([]T, error) {
+	records := make([]T, len(in.Lines))
+	for i, line := range in.Lines {
+		var err error
+		if records[i], err = f.ParseLine(line); err != nil {
+			return nil, err
+		}
+	}
+	return records, nil
+}
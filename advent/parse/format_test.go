@@ -0,0 +1,206 @@
+package parse_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/sirgwain/advent-of-code-2025/advent/parse"
+)
+
+type rotation struct {
+	Dir    byte
+	Clicks int
+}
+
+type machine struct {
+	Lights  string
+	Buttons []string
+	Joltage []int
+}
+
+// switchState is an example of a field that parses itself
+type switchState bool
+
+func (s *switchState) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "on":
+		*s = true
+	case "off":
+		*s = false
+	default:
+		return fmt.Errorf("want on or off")
+	}
+	return nil
+}
+
+// span is an example of a field that parses itself with a Format
+type span struct{ Low, High int }
+
+var spanFormat = parse.MustCompile[span]("{Low}-{High}")
+
+func (s *span) UnmarshalText(text []byte) error {
+	v, err := spanFormat.Parse(string(text))
+	*s = v
+	return err
+}
+
+type light struct {
+	Name  string
+	State switchState
+}
+
+func TestFormat_Parse(t *testing.T) {
+	t.Run("rotation", func(t *testing.T) {
+		f := parse.MustCompile[rotation]("{Dir:c}{Clicks}")
+		got, err := f.Parse("L68")
+		if err != nil || got != (rotation{'L', 68}) {
+			t.Errorf("Parse() = %v, %v, want {L 68}", got, err)
+		}
+	})
+
+	t.Run("machine", func(t *testing.T) {
+		f := parse.MustCompile[machine]("[{Lights}] {Buttons: } {{{Joltage:,}}}")
+		got, err := f.Parse("[.##.] (3) (1,3)  (2) {3,5,4,7}")
+		want := machine{Lights: ".##.", Buttons: []string{"(3)", "(1,3)", "(2)"}, Joltage: []int{3, 5, 4, 7}}
+		if err != nil || !reflect.DeepEqual(got, want) {
+			t.Errorf("Parse() = %v, %v, want %v", got, err, want)
+		}
+	})
+
+	t.Run("small numbers", func(t *testing.T) {
+		// int32 and uint8 are runes and bytes too, but they're numbers unless the pattern says otherwise
+		type record struct {
+			A int32
+			B uint8
+			C rune
+		}
+		f := parse.MustCompile[record]("{A},{B},{C:c}")
+		if got, err := f.Parse("1234,200,é"); err != nil || got != (record{1234, 200, 'é'}) {
+			t.Errorf("Parse() = %v, %v, want {1234 200 é}", got, err)
+		}
+	})
+
+	t.Run("value", func(t *testing.T) {
+		f := parse.MustCompile[int64]("{}")
+		if got, err := f.Parse("-42"); err != nil || got != -42 {
+			t.Errorf("Parse() = %v, %v, want -42", got, err)
+		}
+	})
+
+	t.Run("digits", func(t *testing.T) {
+		f := parse.MustCompile[[]int]("{:}")
+		if got, err := f.Parse("987"); err != nil || !reflect.DeepEqual(got, []int{9, 8, 7}) {
+			t.Errorf("Parse() = %v, %v, want [9 8 7]", got, err)
+		}
+	})
+
+	t.Run("unmarshaler", func(t *testing.T) {
+		f := parse.MustCompile[light]("{Name} is {State}")
+		if got, err := f.Parse("porch is on"); err != nil || got != (light{"porch", true}) {
+			t.Errorf("Parse() = %v, %v, want {porch true}", got, err)
+		}
+	})
+}
+
+func TestFormat_ParseErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		wantCol int
+		wantErr string
+	}{
+		{"bad number", "3-x5", 3, `invalid High: not a valid int: "x5"`},
+		{"missing separator", "35", 1, `expected "-" after Low: "35"`},
+		{"trailing text", "3-5 ", 3, `invalid High: not a valid int: "5 "`},
+		{"empty", "", 1, `expected "-" after Low`},
+	}
+	f := parse.MustCompile[struct{ Low, High int }]("{Low}-{High}")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := f.Parse(tt.text)
+			var perr *parse.Error
+			if !errors.As(err, &perr) {
+				t.Fatalf("Parse() error = %v, want a *parse.Error", err)
+			}
+			if perr.Column != tt.wantCol || perr.Error() != tt.wantErr {
+				t.Errorf("Parse() error = %q at column %d, want %q at column %d", perr.Error(), perr.Column, tt.wantErr, tt.wantCol)
+			}
+		})
+	}
+
+	t.Run("slice element", func(t *testing.T) {
+		f := parse.MustCompile[machine]("[{Lights}] {Buttons: } {{{Joltage:,}}}")
+		_, err := f.Parse("[.#] (1) {3,é,4}")
+		var perr *parse.Error
+		if !errors.As(err, &perr) || perr.Column != 13 || perr.Token != "é" {
+			t.Errorf("Parse() error = %#v, want column 13 at é", err)
+		}
+	})
+
+	t.Run("nested format", func(t *testing.T) {
+		f := parse.MustCompile[[]span]("{:,}")
+		_, err := f.Parse("11-22,95-1x5")
+		var perr *parse.Error
+		if !errors.As(err, &perr) || perr.Column != 10 || perr.Token != "1x5" {
+			t.Errorf("Parse() error = %#v, want column 10 at 1x5", err)
+		}
+	})
+
+	t.Run("unmarshaler", func(t *testing.T) {
+		f := parse.MustCompile[light]("{Name} is {State}")
+		_, err := f.Parse("porch is dim")
+		if err == nil || err.Error() != `invalid State: want on or off: "dim"` {
+			t.Errorf("Parse() error = %v", err)
+		}
+	})
+}
+
+func TestCompile(t *testing.T) {
+	tests := []struct {
+		name    string
+		compile func() error
+		wantErr bool
+	}{
+		{"ok", func() error { _, err := parse.Compile[rotation]("{Dir:c}{Clicks}"); return err }, false},
+		{"adjacent fields", func() error { _, err := parse.Compile[rotation]("{Clicks}{Dir:c}"); return err }, true},
+		{"adjacent bytes", func() error { _, err := parse.Compile[rotation]("{Dir}{Clicks}"); return err }, true},
+		{"character of a number", func() error { _, err := parse.Compile[rotation]("{Dir}-{Clicks:c}"); return err }, true},
+		{"unknown field", func() error { _, err := parse.Compile[rotation]("{Dir:c}{Count}"); return err }, true},
+		{"slice without separator", func() error { _, err := parse.Compile[machine]("{Joltage}"); return err }, true},
+		{"separator on a scalar", func() error { _, err := parse.Compile[machine]("{Lights:,}"); return err }, true},
+		{"unclosed", func() error { _, err := parse.Compile[machine]("{Lights"); return err }, true},
+		{"unmatched close", func() error { _, err := parse.Compile[machine]("{Lights}}"); return err }, true},
+		{"stray close", func() error { _, err := parse.Compile[machine]("Lights}"); return err }, true},
+		{"named field of a value", func() error { _, err := parse.Compile[int]("{Value}"); return err }, true},
+		{"unsupported type", func() error { _, err := parse.Compile[struct{ M map[int]int }]("{M}"); return err }, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.compile(); (err != nil) != tt.wantErr {
+				t.Errorf("Compile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFormat_Records(t *testing.T) {
+	in := parse.FromString("input.txt", "3-5\r\n10-14\r\n\r\n16-2o\r\n\r\n")
+	sections := in.Sections()
+	if len(sections) != 2 || sections[1].Lines[0].Num != 4 {
+		t.Fatalf("Sections() = %v, want 2 sections with the second starting on line 4", sections)
+	}
+
+	f := parse.MustCompile[struct{ Low, High int }]("{Low}-{High}")
+	ranges, err := f.Records(sections[0])
+	if err != nil || len(ranges) != 2 || ranges[1].High != 14 {
+		t.Errorf("Records() = %v, %v", ranges, err)
+	}
+
+	_, err = f.Records(sections[1])
+	want := `input.txt:4:4: invalid High: not a valid int: "2o"`
+	if err == nil || err.Error() != want {
+		t.Errorf("Records() error = %v, want %s", err, want)
+	}
+}
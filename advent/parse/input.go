@@ -0,0 +1,94 @@
+// Package parse reads puzzle input into typed records. A day describes the format of a line once, as a pattern
+// like "{Low}-{High}", and gets back values plus errors that say exactly where the input went wrong.
+package parse
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Line is one line of input and where it came from
+type Line struct {
+	File string
+	Num  int // 1-based
+	Text string
+}
+
+// Errorf makes an error pointing at a column of the line, 1-based, or 0 for the whole line
+func (l Line) Errorf(column int, format string, args ...any) *Error {
+	return &Error{File: l.File, Line: l.Num, Column: column, Text: l.Text, Err: fmt.Errorf(format, args...)}
+}
+
+// Input is the lines of a puzzle input
+type Input struct {
+	File  string
+	Lines []Line
+}
+
+// ReadFile reads a whole input file
+func ReadFile(filename string) (*Input, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("error opening file: %w", err)
+	}
+	defer file.Close()
+	return Read(filename, file)
+}
+
+// Read reads a whole input, name is used in errors
+func Read(name string, r io.Reader) (*Input, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", name, err)
+	}
+	return FromString(name, string(content)), nil
+}
+
+// FromString splits text into an input. Trailing newlines don't make extra blank lines.
+func FromString(name, text string) *Input {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.TrimRight(text, "\n")
+	in := &Input{File: name}
+	if text == "" {
+		return in
+	}
+	for i, line := range strings.Split(text, "\n") {
+		in.Lines = append(in.Lines, Line{File: name, Num: i + 1, Text: line})
+	}
+	return in
+}
+
+// Errorf makes an error about the input as a whole, like a missing section
+func (in *Input) Errorf(format string, args ...any) *Error {
+	return &Error{File: in.File, Err: fmt.Errorf(format, args...)}
+}
+
+// Text returns the text of every line
+func (in *Input) Text() []string {
+	text := make([]string, len(in.Lines))
+	for i, line := range in.Lines {
+		text[i] = line.Text
+	}
+	return text
+}
+
+// Sections splits the input at blank lines, like the ranges and ids of day 5. Runs of blank lines and blank lines
+// at the start or end don't make empty sections. Each section keeps its original line numbers.
+func (in *Input) Sections() []*Input {
+	var sections []*Input
+	var current *Input
+	for _, line := range in.Lines {
+		if strings.TrimSpace(line.Text) == "" {
+			current = nil
+			continue
+		}
+		if current == nil {
+			current = &Input{File: in.File}
+			sections = append(sections, current)
+		}
+		current.Lines = append(current.Lines, line)
+	}
+	return sections
+}
@@ -0,0 +1,95 @@
+package advent
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/sirgwain/advent-of-code-2025/advent/parse"
+)
+
+// ParseError is puzzle input that doesn't match what a day expects. Every Init returns one for bad input, so the
+// cli can point at the problem instead of the day panicking halfway through a run.
+type ParseError struct {
+	Day    int
+	File   string
+	Line   int    // 1-based, 0 if the problem is with the whole file
+	Column int    // 1-based, 0 if the problem is with the whole line
+	Text   string // the line with the problem
+	Token  string // the text that's wrong, if there is some
+	Reason string
+}
+
+func (e *ParseError) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "day %d: ", e.Day)
+	if e.File != "" {
+		sb.WriteString(e.File + ":")
+	}
+	if e.Line > 0 {
+		fmt.Fprintf(&sb, "%d:", e.Line)
+		if e.Column > 0 {
+			fmt.Fprintf(&sb, "%d:", e.Column)
+		}
+	}
+	if e.File != "" || e.Line > 0 {
+		sb.WriteString(" ")
+	}
+	sb.WriteString(e.Reason)
+	if e.Token != "" {
+		fmt.Fprintf(&sb, ": %q", e.Token)
+	}
+	return sb.String()
+}
+
+// Caret shows the line with a caret under the problem, like a compiler error
+//
+//	3 | 16-2o
+//	  |    ^^
+//
+// It's empty if the error isn't from a line.
+func (e *ParseError) Caret() string {
+	if e.Line == 0 {
+		return ""
+	}
+	gutter := fmt.Sprintf("%d", e.Line)
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s | %s\n", gutter, e.Text)
+	if e.Column == 0 {
+		return strings.TrimSuffix(sb.String(), "\n")
+	}
+
+	// keep tabs so the caret lines up with the text above it
+	fmt.Fprintf(&sb, "%s | ", strings.Repeat(" ", len(gutter)))
+	for i, r := range []rune(e.Text) {
+		if i >= e.Column-1 {
+			break
+		}
+		if r == '\t' {
+			sb.WriteRune('\t')
+		} else {
+			sb.WriteRune(' ')
+		}
+	}
+	sb.WriteString(strings.Repeat("^", max(utf8.RuneCountInString(e.Token), 1)))
+	return sb.String()
+}
+
+// asParseError turns errors from the parse package into a ParseError for a day. Other errors, like a missing
+// file, are returned as they are.
+func asParseError(day int, err error) error {
+	var perr *parse.Error
+	if !errors.As(err, &perr) {
+		return err
+	}
+	return &ParseError{
+		Day:    day,
+		File:   perr.File,
+		Line:   perr.Line,
+		Column: perr.Column,
+		Text:   perr.Text,
+		Token:  perr.Token,
+		Reason: perr.Err.Error(),
+	}
+}
@@ -0,0 +1,75 @@
+package advent
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestInit_ParseErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		day        Day
+		input      string
+		wantLine   int
+		wantColumn int
+	}{
+		{"day1 blank line", &Day1{}, "L68\n\nR5\n", 2, 1},
+		{"day1 bad direction", &Day1{}, "L68\nU5\n", 2, 1},
+		{"day1 bad number", &Day1{}, "L68\nR5x\n", 2, 2},
+		{"day2 missing high", &Day2{}, "11-22,95\n", 1, 7},
+		{"day2 bad high", &Day2{}, "11-22,95-1x5\n", 1, 10},
+		{"day3 not a digit", &Day3{}, "987654321111111\n81111a111111119\n", 2, 6},
+		{"day3 too short", &Day3{}, "98765\n", 1, 0},
+		{"day4 unknown cell", &Day4{}, "..@\n.#.\n", 2, 2},
+		{"day5 one section", &Day5{}, "3-5\n10-14\n", 0, 0},
+		{"day5 bad id", &Day5{}, "3-5\n\n1\n2x\n", 4, 1},
+		{"day6 bad operator", &Day6{}, "1 2\n* -\n", 2, 3},
+		{"day6 missing number", &Day6{}, "1 2\n3\n* +\n", 2, 0},
+		{"day7 no start", &Day7{}, "...\n.^.\n", 1, 0},
+		{"day8 missing z", &Day8{}, "162,817,812\n57,618\n", 2, 4},
+		{"day9 bad y", &Day9{}, "7,1\n11,-\n", 2, 4},
+		{"day10 bad light", &Day10{}, "[.#x] (0) {1,2,3}\n", 1, 4},
+		{"day10 bad button", &Day10{}, "[.#] (0) 1,2 {1,2}\n", 1, 10},
+		{"day10 button out of range", &Day10{}, "[.#] (0) (2) {1,2}\n", 1, 10},
+		{"day10 joltage for each light", &Day10{}, "[.#] (0) {1}\n", 1, 10},
+		{"day11 missing colon", &Day11{}, "you: out\nsvr out\n", 2, 1},
+		{"day12 bad cell", &Day12{}, "0:\n#x\n\n2x2: 1\n", 2, 2},
+		{"day12 too many shapes", &Day12{}, "0:\n#\n\n2x2: 1 1\n", 4, 0},
+		{"empty", &Day1{}, "\n", 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.day.Init(strings.NewReader(tt.input), NewRun(WithQuiet(true), WithInputName("input.txt")))
+			var perr *ParseError
+			if !errors.As(err, &perr) {
+				t.Fatalf("Init() error = %v, want a *ParseError", err)
+			}
+			if perr.Day != tt.day.Day() || perr.File != "input.txt" || perr.Line != tt.wantLine || perr.Column != tt.wantColumn {
+				t.Errorf("Init() error = %v at day %d %d:%d, want day %d %d:%d",
+					err, perr.Day, perr.Line, perr.Column, tt.day.Day(), tt.wantLine, tt.wantColumn)
+			}
+		})
+	}
+}
+
+func TestParseError_Caret(t *testing.T) {
+	tests := []struct {
+		name string
+		err  ParseError
+		want string
+	}{
+		{"token", ParseError{Line: 12, Column: 4, Text: "16-2o", Token: "2o"}, "12 | 16-2o\n   |    ^^"},
+		{"tabs", ParseError{Line: 3, Column: 3, Text: "\t1x", Token: "x"}, "3 | \t1x\n  | \t ^"},
+		{"no token", ParseError{Line: 1, Column: 1, Text: "", Token: ""}, "1 | \n  | ^"},
+		{"whole line", ParseError{Line: 2, Text: "98765"}, "2 | 98765"},
+		{"whole file", ParseError{Reason: "input is empty"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.Caret(); got != tt.want {
+				t.Errorf("Caret() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
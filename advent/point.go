@@ -2,7 +2,9 @@ package advent
 
 import (
 	"fmt"
-	"slices"
+	"iter"
+	"strconv"
+	"strings"
 )
 
 type Point struct {
@@ -14,126 +16,155 @@ type PositionDirection struct {
 	Direction Direction
 }
 
-func absInt(x int) int {
+// Abs returns the absolute value of x
+func Abs(x int) int {
 	if x < 0 {
 		return -x
 	}
 	return x
 }
 
-func (p Point) addDirection(dir Direction) Point {
-	x, y := dir.OffsetMultiplier()
-	return Point{p.X + x, p.Y + y}
+// Add returns p + o
+func (p Point) Add(o Point) Point {
+	return Point{p.X + o.X, p.Y + o.Y}
 }
 
-func (p Point) String() string {
-	return fmt.Sprintf("%d, %d", p.X, p.Y)
+// Sub returns p - o
+func (p Point) Sub(o Point) Point {
+	return Point{p.X - o.X, p.Y - o.Y}
 }
 
-func area(p1, p2 Point) int {
-	dx := absInt(p1.X-p2.X) + 1
-	dy := absInt((p2.Y - p1.Y)) + 1
-	return int(dx * dy)
+// Scale multiplies both coordinates by k
+func (p Point) Scale(k int) Point {
+	return Point{p.X * k, p.Y * k}
 }
 
-// pointInPolygon returns true if p is inside poly.
-func pointInPolygon(p Point, poly []Point) bool {
-	inside := false
-
-	n := len(poly)
-	for i, j := 0, n-1; i < n; j, i = i, i+1 {
-		pi := poly[i]
-		pj := poly[j]
+// Manhattan is the taxicab distance between p and o
+func (p Point) Manhattan(o Point) int {
+	return Abs(p.X-o.X) + Abs(p.Y-o.Y)
+}
 
-		if pointOnSegment(p, pi, pj) {
-			return true
-		}
+// Chebyshev is the king's move distance between p and o, diagonals count as one step
+func (p Point) Chebyshev(o Point) int {
+	return max(Abs(p.X-o.X), Abs(p.Y-o.Y))
+}
 
-		intersects := ((pi.Y > p.Y) != (pj.Y > p.Y)) &&
-			(p.X < (pj.X-pi.X)*(p.Y-pi.Y)/(pj.Y-pi.Y)+pi.X)
+// Step moves dist cells in a direction
+func (p Point) Step(dir Direction, dist int) Point {
+	x, y := dir.OffsetMultiplier()
+	return Point{p.X + x*dist, p.Y + y*dist}
+}
 
-		if intersects {
-			inside = !inside
+// Neighbors iterates over the points one step away in each direction
+func (p Point) Neighbors(directions []Direction) iter.Seq[Point] {
+	return func(yield func(Point) bool) {
+		for _, dir := range directions {
+			if !yield(p.Step(dir, 1)) {
+				return
+			}
 		}
 	}
+}
 
-	return inside
+// Neighbors4 iterates over the up, right, down and left neighbors of p
+func (p Point) Neighbors4() iter.Seq[Point] {
+	return p.Neighbors(CardinalDirections)
 }
 
-func pointOnSegment(p, a, b Point) bool {
-	cross := (p.Y-a.Y)*(b.X-a.X) - (p.X-a.X)*(b.Y-a.Y)
-	if cross != 0 {
-		return false
-	}
-	dot := (p.X-a.X)*(b.X-a.X) + (p.Y-a.Y)*(b.Y-a.Y)
-	if dot < 0 {
-		return false
-	}
-	lengthSq := (b.X-a.X)*(b.X-a.X) + (b.Y-a.Y)*(b.Y-a.Y)
-	return dot <= lengthSq
+// Neighbors8 iterates over all neighbors of p, including diagonals
+func (p Point) Neighbors8() iter.Seq[Point] {
+	return p.Neighbors(AdjacentDirections)
 }
 
-// On a horizontal "row" y-band between y and y+1,
-// test if [x1, x2] is completely inside the polygon.
-func rowInside(poly []Point, y, x1, x2 int) bool {
-	if x1 > x2 {
-		x1, x2 = x2, x1
+// Rotate rotates p about the origin by quarter turns clockwise. Negative turns rotate counter clockwise.
+// Like the rest of the board, y grows down, so Up rotates to Right.
+func (p Point) Rotate(quarterTurns int) Point {
+	switch ((quarterTurns % 4) + 4) % 4 {
+	case 1:
+		return Point{-p.Y, p.X}
+	case 2:
+		return Point{-p.X, -p.Y}
+	case 3:
+		return Point{p.Y, -p.X}
 	}
+	return p
+}
 
-	var xs []int
-	n := len(poly)
-
-	for i, j := 0, n-1; i < n; j, i = i, i+1 {
-		a := poly[j]
-		b := poly[i]
+func (p Point) String() string {
+	return fmt.Sprintf("%d, %d", p.X, p.Y)
+}
 
-		// Only vertical edges matter for a horizontal scan.
-		if a.X != b.X {
-			continue
-		}
+// MarshalText encodes p as "x,y" so points can be used as JSON keys
+func (p Point) MarshalText() ([]byte, error) {
+	return []byte(strconv.Itoa(p.X) + "," + strconv.Itoa(p.Y)), nil
+}
 
-		// Normalize so a.Y <= b.Y
-		if a.Y > b.Y {
-			a, b = b, a
-		}
+// UnmarshalText decodes "x,y", allowing spaces around either value
+func (p *Point) UnmarshalText(text []byte) error {
+	xs, ys, ok := strings.Cut(string(text), ",")
+	if !ok {
+		return fmt.Errorf("invalid point %q, expected x,y", text)
+	}
+	x, err := strconv.Atoi(strings.TrimSpace(xs))
+	if err != nil {
+		return fmt.Errorf("invalid point %q: %w", text, err)
+	}
+	y, err := strconv.Atoi(strings.TrimSpace(ys))
+	if err != nil {
+		return fmt.Errorf("invalid point %q: %w", text, err)
+	}
+	p.X, p.Y = x, y
+	return nil
+}
 
-		// We conceptually sample at y+0.5.
-		// That lies in the band [a.Y, b.Y) exactly when:
-		// a.Y <= y+0.5 < b.Y  <=>  a.Y <= y and y+1 <= b.Y
-		if !(a.Y <= y && y+1 <= b.Y) {
-			continue
-		}
+// Rect is a bounding box. Min and Max are both inside the box.
+type Rect struct {
+	Min Point
+	Max Point
+}
 
-		xs = append(xs, a.X)
+// NewRect makes the smallest Rect with p1 and p2 as corners
+func NewRect(p1, p2 Point) Rect {
+	return Rect{
+		Min: Point{min(p1.X, p2.X), min(p1.Y, p2.Y)},
+		Max: Point{max(p1.X, p2.X), max(p1.Y, p2.Y)},
 	}
+}
 
-	if len(xs) == 0 {
-		// This row never enters the polygon.
-		return false
+// BoundingBox returns the smallest Rect containing every point, or false if there are no points
+func BoundingBox(points []Point) (Rect, bool) {
+	if len(points) == 0 {
+		return Rect{}, false
 	}
-
-	slices.Sort(xs)
-
-	inside := false
-	prevX := 0
-
-	// We’ll build the union of inside intervals as we sweep.
-	for _, x := range xs {
-		if !inside {
-			// Entering polygon at x
-			prevX = x
-			inside = true
-		} else {
-			// Leaving polygon at x, interior is [prevX, x]
-			// For axis-aligned / integer, treat boundary as inside.
-			// Check if our whole [x1, x2] fits in this interior run.
-			if x1 >= prevX && x2 <= x {
-				return true
-			}
-			inside = false
-		}
+	r := Rect{points[0], points[0]}
+	for _, p := range points[1:] {
+		r = r.Union(Rect{p, p})
 	}
+	return r, true
+}
+
+func (r Rect) Width() int {
+	return r.Max.X - r.Min.X + 1
+}
+
+func (r Rect) Height() int {
+	return r.Max.Y - r.Min.Y + 1
+}
 
-	// Should end outside for a proper closed polygon.
-	return false
+// Area is the number of cells in the box
+func (r Rect) Area() int {
+	return r.Width() * r.Height()
+}
+
+// Contains is true if p is inside or on the edge of the box
+func (r Rect) Contains(p Point) bool {
+	return p.X >= r.Min.X && p.X <= r.Max.X && p.Y >= r.Min.Y && p.Y <= r.Max.Y
+}
+
+// Union returns the smallest Rect containing both r and o
+func (r Rect) Union(o Rect) Rect {
+	return Rect{
+		Min: Point{min(r.Min.X, o.Min.X), min(r.Min.Y, o.Min.Y)},
+		Max: Point{max(r.Max.X, o.Max.X), max(r.Max.Y, o.Max.Y)},
+	}
 }
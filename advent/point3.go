@@ -0,0 +1,227 @@
+package advent
+
+import (
+	"fmt"
+	"iter"
+	"strconv"
+	"strings"
+)
+
+// Point3 is a position in 3D space
+type Point3 struct {
+	X int
+	Y int
+	Z int
+}
+
+// Neighbors6 are the offsets to the faces of a cube
+var Neighbors6 = []Point3{
+	{0, 0, -1}, {0, -1, 0}, {-1, 0, 0},
+	{1, 0, 0}, {0, 1, 0}, {0, 0, 1},
+}
+
+// Neighbors26 are the offsets to the faces, edges and corners of a cube
+var Neighbors26 = func() []Point3 {
+	var offsets []Point3
+	for z := -1; z <= 1; z++ {
+		for y := -1; y <= 1; y++ {
+			for x := -1; x <= 1; x++ {
+				if x == 0 && y == 0 && z == 0 {
+					continue
+				}
+				offsets = append(offsets, Point3{x, y, z})
+			}
+		}
+	}
+	return offsets
+}()
+
+// Add returns p + o
+func (p Point3) Add(o Point3) Point3 {
+	return Point3{p.X + o.X, p.Y + o.Y, p.Z + o.Z}
+}
+
+// Sub returns p - o
+func (p Point3) Sub(o Point3) Point3 {
+	return Point3{p.X - o.X, p.Y - o.Y, p.Z - o.Z}
+}
+
+// Scale multiplies every coordinate by k
+func (p Point3) Scale(k int) Point3 {
+	return Point3{p.X * k, p.Y * k, p.Z * k}
+}
+
+// Manhattan is the taxicab distance between p and o
+func (p Point3) Manhattan(o Point3) int {
+	return Abs(p.X-o.X) + Abs(p.Y-o.Y) + Abs(p.Z-o.Z)
+}
+
+// Chebyshev is the largest distance along any one axis
+func (p Point3) Chebyshev(o Point3) int {
+	return max(Abs(p.X-o.X), Abs(p.Y-o.Y), Abs(p.Z-o.Z))
+}
+
+// DistSquared is the squared straight line distance between p and o. It's enough to compare distances
+// without a square root.
+func (p Point3) DistSquared(o Point3) int64 {
+	dx := int64(p.X - o.X)
+	dy := int64(p.Y - o.Y)
+	dz := int64(p.Z - o.Z)
+	return dx*dx + dy*dy + dz*dz
+}
+
+// Neighbors iterates over p plus each offset
+func (p Point3) Neighbors(offsets []Point3) iter.Seq[Point3] {
+	return func(yield func(Point3) bool) {
+		for _, o := range offsets {
+			if !yield(p.Add(o)) {
+				return
+			}
+		}
+	}
+}
+
+// Neighbors6 iterates over the points sharing a face with p
+func (p Point3) Neighbors6() iter.Seq[Point3] {
+	return p.Neighbors(Neighbors6)
+}
+
+// Neighbors26 iterates over every point touching p
+func (p Point3) Neighbors26() iter.Seq[Point3] {
+	return p.Neighbors(Neighbors26)
+}
+
+func (p Point3) String() string {
+	return fmt.Sprintf("%d, %d, %d", p.X, p.Y, p.Z)
+}
+
+// MarshalText encodes p as "x,y,z", the same format as the puzzle inputs
+func (p Point3) MarshalText() ([]byte, error) {
+	return []byte(strconv.Itoa(p.X) + "," + strconv.Itoa(p.Y) + "," + strconv.Itoa(p.Z)), nil
+}
+
+// UnmarshalText decodes "x,y,z", allowing spaces around each value
+func (p *Point3) UnmarshalText(text []byte) error {
+	parts := strings.Split(string(text), ",")
+	if len(parts) != 3 {
+		return fmt.Errorf("invalid point %q, expected x,y,z", text)
+	}
+	var coords [3]int
+	for i, part := range parts {
+		v, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return fmt.Errorf("invalid point %q: %w", text, err)
+		}
+		coords[i] = v
+	}
+	p.X, p.Y, p.Z = coords[0], coords[1], coords[2]
+	return nil
+}
+
+// Box3 is a 3D bounding box. Min and Max are both inside the box.
+type Box3 struct {
+	Min Point3
+	Max Point3
+}
+
+// NewBox3 makes the smallest Box3 with p1 and p2 as corners
+func NewBox3(p1, p2 Point3) Box3 {
+	return Box3{
+		Min: Point3{min(p1.X, p2.X), min(p1.Y, p2.Y), min(p1.Z, p2.Z)},
+		Max: Point3{max(p1.X, p2.X), max(p1.Y, p2.Y), max(p1.Z, p2.Z)},
+	}
+}
+
+// BoundingBox3 returns the smallest Box3 containing every point, or false if there are no points
+func BoundingBox3(points iter.Seq[Point3]) (Box3, bool) {
+	var b Box3
+	found := false
+	for p := range points {
+		if !found {
+			b = Box3{p, p}
+			found = true
+			continue
+		}
+		b = b.Union(Box3{p, p})
+	}
+	return b, found
+}
+
+// Volume is the number of cells in the box
+func (b Box3) Volume() int {
+	return (b.Max.X - b.Min.X + 1) * (b.Max.Y - b.Min.Y + 1) * (b.Max.Z - b.Min.Z + 1)
+}
+
+// Contains is true if p is inside or on the surface of the box
+func (b Box3) Contains(p Point3) bool {
+	return p.X >= b.Min.X && p.X <= b.Max.X &&
+		p.Y >= b.Min.Y && p.Y <= b.Max.Y &&
+		p.Z >= b.Min.Z && p.Z <= b.Max.Z
+}
+
+// Union returns the smallest Box3 containing both b and o
+func (b Box3) Union(o Box3) Box3 {
+	return Box3{
+		Min: Point3{min(b.Min.X, o.Min.X), min(b.Min.Y, o.Min.Y), min(b.Min.Z, o.Min.Z)},
+		Max: Point3{max(b.Max.X, o.Max.X), max(b.Max.Y, o.Max.Y), max(b.Max.Z, o.Max.Z)},
+	}
+}
+
+// VoxelSet is a sparse set of filled 3D cells
+type VoxelSet struct {
+	voxels map[Point3]struct{}
+}
+
+func NewVoxelSet(points ...Point3) *VoxelSet {
+	s := &VoxelSet{voxels: make(map[Point3]struct{}, len(points))}
+	for _, p := range points {
+		s.Add(p)
+	}
+	return s
+}
+
+func (s *VoxelSet) Add(p Point3) {
+	s.voxels[p] = struct{}{}
+}
+
+func (s *VoxelSet) Remove(p Point3) {
+	delete(s.voxels, p)
+}
+
+func (s *VoxelSet) Contains(p Point3) bool {
+	_, ok := s.voxels[p]
+	return ok
+}
+
+func (s *VoxelSet) Len() int {
+	return len(s.voxels)
+}
+
+// All iterates over every filled cell in no particular order
+func (s *VoxelSet) All() iter.Seq[Point3] {
+	return func(yield func(Point3) bool) {
+		for p := range s.voxels {
+			if !yield(p) {
+				return
+			}
+		}
+	}
+}
+
+// Bounds returns the bounding box of the set, or false if it's empty
+func (s *VoxelSet) Bounds() (Box3, bool) {
+	return BoundingBox3(s.All())
+}
+
+// SurfaceArea counts the faces of filled cells that aren't touching another filled cell
+func (s *VoxelSet) SurfaceArea() int {
+	area := 0
+	for p := range s.voxels {
+		for n := range p.Neighbors6() {
+			if !s.Contains(n) {
+				area++
+			}
+		}
+	}
+	return area
+}
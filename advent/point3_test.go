@@ -0,0 +1,112 @@
+package advent
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPoint3_Distance(t *testing.T) {
+	tests := []struct {
+		name            string
+		p               Point3
+		o               Point3
+		wantManhattan   int
+		wantChebyshev   int
+		wantDistSquared int64
+	}{
+		{name: "same point", p: Point3{1, 2, 3}, o: Point3{1, 2, 3}},
+		{name: "one axis", p: Point3{0, 0, 0}, o: Point3{0, 0, 4}, wantManhattan: 4, wantChebyshev: 4, wantDistSquared: 16},
+		{name: "diagonal", p: Point3{1, 1, 1}, o: Point3{-1, 2, 4}, wantManhattan: 6, wantChebyshev: 3, wantDistSquared: 14},
+		{name: "large", p: Point3{0, 0, 0}, o: Point3{100000, 100000, 100000}, wantManhattan: 300000, wantChebyshev: 100000, wantDistSquared: 30000000000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.p.Manhattan(tt.o); got != tt.wantManhattan {
+				t.Errorf("Point3.Manhattan() = %v, want %v", got, tt.wantManhattan)
+			}
+			if got := tt.p.Chebyshev(tt.o); got != tt.wantChebyshev {
+				t.Errorf("Point3.Chebyshev() = %v, want %v", got, tt.wantChebyshev)
+			}
+			if got := tt.p.DistSquared(tt.o); got != tt.wantDistSquared {
+				t.Errorf("Point3.DistSquared() = %v, want %v", got, tt.wantDistSquared)
+			}
+		})
+	}
+}
+
+func TestPoint3_Neighbors(t *testing.T) {
+	p := Point3{5, 5, 5}
+	for name, neighbors := range map[string][]Point3{
+		"Neighbors6":  slices.Collect(p.Neighbors6()),
+		"Neighbors26": slices.Collect(p.Neighbors26()),
+	} {
+		seen := map[Point3]bool{}
+		for _, n := range neighbors {
+			if n.Chebyshev(p) != 1 {
+				t.Errorf("Point3.%s() returned %v, which isn't adjacent", name, n)
+			}
+			seen[n] = true
+		}
+		want := 6
+		if name == "Neighbors26" {
+			want = 26
+		}
+		if len(seen) != want {
+			t.Errorf("Point3.%s() returned %d unique neighbors, want %d", name, len(seen), want)
+		}
+	}
+}
+
+func TestPoint3_UnmarshalText(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    Point3
+		wantErr bool
+	}{
+		{name: "input format", text: "162,817,812", want: Point3{162, 817, 812}},
+		{name: "spaces", text: "1, -2, 3", want: Point3{1, -2, 3}},
+		{name: "too few", text: "1,2", wantErr: true},
+		{name: "not a number", text: "1,b,3", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Point3
+			err := got.UnmarshalText([]byte(tt.text))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Point3.UnmarshalText() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Point3.UnmarshalText() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVoxelSet(t *testing.T) {
+	// two cubes sharing a face
+	s := NewVoxelSet(Point3{1, 1, 1}, Point3{2, 1, 1})
+	if got := s.SurfaceArea(); got != 10 {
+		t.Errorf("VoxelSet.SurfaceArea() = %v, want 10", got)
+	}
+
+	s.Add(Point3{0, 3, -1})
+	bounds, ok := s.Bounds()
+	if !ok || bounds != (Box3{Point3{0, 1, -1}, Point3{2, 3, 1}}) {
+		t.Errorf("VoxelSet.Bounds() = %v, %v", bounds, ok)
+	}
+	if got := bounds.Volume(); got != 27 {
+		t.Errorf("Box3.Volume() = %v, want 27", got)
+	}
+	if !bounds.Contains(Point3{1, 2, 0}) || bounds.Contains(Point3{3, 2, 0}) {
+		t.Errorf("Box3.Contains() is wrong for %v", bounds)
+	}
+
+	s.Remove(Point3{0, 3, -1})
+	if s.Contains(Point3{0, 3, -1}) || s.Len() != 2 {
+		t.Errorf("VoxelSet.Remove() left %d voxels", s.Len())
+	}
+	if _, ok := NewVoxelSet().Bounds(); ok {
+		t.Errorf("VoxelSet.Bounds() of an empty set should be false")
+	}
+}
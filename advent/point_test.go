@@ -0,0 +1,152 @@
+package advent
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestPoint_Distance(t *testing.T) {
+	tests := []struct {
+		name          string
+		p             Point
+		o             Point
+		wantManhattan int
+		wantChebyshev int
+	}{
+		{name: "same point", p: Point{3, 4}, o: Point{3, 4}, wantManhattan: 0, wantChebyshev: 0},
+		{name: "horizontal", p: Point{0, 0}, o: Point{5, 0}, wantManhattan: 5, wantChebyshev: 5},
+		{name: "diagonal", p: Point{0, 0}, o: Point{3, 3}, wantManhattan: 6, wantChebyshev: 3},
+		{name: "negative", p: Point{-2, 1}, o: Point{1, -3}, wantManhattan: 7, wantChebyshev: 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.p.Manhattan(tt.o); got != tt.wantManhattan {
+				t.Errorf("Point.Manhattan() = %v, want %v", got, tt.wantManhattan)
+			}
+			if got := tt.p.Chebyshev(tt.o); got != tt.wantChebyshev {
+				t.Errorf("Point.Chebyshev() = %v, want %v", got, tt.wantChebyshev)
+			}
+		})
+	}
+}
+
+func TestPoint_Step(t *testing.T) {
+	tests := []struct {
+		name string
+		dir  Direction
+		dist int
+		want Point
+	}{
+		{name: "up", dir: DirectionUp, dist: 1, want: Point{2, 1}},
+		{name: "right 3", dir: DirectionRight, dist: 3, want: Point{5, 2}},
+		{name: "down left 2", dir: DirectionDownLeft, dist: 2, want: Point{0, 4}},
+		{name: "backwards", dir: DirectionDown, dist: -2, want: Point{2, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := (Point{2, 2}).Step(tt.dir, tt.dist); got != tt.want {
+				t.Errorf("Point.Step() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPoint_Rotate(t *testing.T) {
+	tests := []struct {
+		name         string
+		quarterTurns int
+		want         Point
+	}{
+		{name: "none", quarterTurns: 0, want: Point{0, -1}},
+		{name: "clockwise", quarterTurns: 1, want: Point{1, 0}},
+		{name: "half", quarterTurns: 2, want: Point{0, 1}},
+		{name: "counter clockwise", quarterTurns: -1, want: Point{-1, 0}},
+		{name: "full circle", quarterTurns: 4, want: Point{0, -1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// rotating up by a quarter turn points right
+			if got := (Point{0, -1}).Rotate(tt.quarterTurns); got != tt.want {
+				t.Errorf("Point.Rotate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPoint_Neighbors(t *testing.T) {
+	p := Point{1, 1}
+	got := slices.Collect(p.Neighbors4())
+	want := []Point{{1, 0}, {2, 1}, {1, 2}, {0, 1}}
+	if !slices.Equal(got, want) {
+		t.Errorf("Point.Neighbors4() = %v, want %v", got, want)
+	}
+	if got := slices.Collect(p.Neighbors8()); len(got) != 8 {
+		t.Errorf("Point.Neighbors8() = %v, want 8 neighbors", got)
+	}
+}
+
+func TestPoint_MarshalText(t *testing.T) {
+	m := map[Point]int{{1, -2}: 3}
+	data, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if string(data) != `{"1,-2":3}` {
+		t.Errorf("json.Marshal() = %s", data)
+	}
+
+	var got map[Point]int
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if got[Point{1, -2}] != 3 {
+		t.Errorf("json.Unmarshal() = %v", got)
+	}
+
+	var p Point
+	if err := p.UnmarshalText([]byte("4, 5")); err != nil || p != (Point{4, 5}) {
+		t.Errorf("Point.UnmarshalText() = %v, %v", p, err)
+	}
+	if err := p.UnmarshalText([]byte("4")); err == nil {
+		t.Errorf("Point.UnmarshalText() expected error")
+	}
+}
+
+func TestRect(t *testing.T) {
+	r := NewRect(Point{9, 7}, Point{2, 3})
+	if r.Min != (Point{2, 3}) || r.Max != (Point{9, 7}) {
+		t.Errorf("NewRect() = %v", r)
+	}
+	if got := r.Area(); got != 40 {
+		t.Errorf("Rect.Area() = %v, want 40", got)
+	}
+
+	tests := []struct {
+		name string
+		p    Point
+		want bool
+	}{
+		{name: "inside", p: Point{5, 5}, want: true},
+		{name: "corner", p: Point{9, 7}, want: true},
+		{name: "edge", p: Point{2, 5}, want: true},
+		{name: "outside", p: Point{10, 5}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := r.Contains(tt.p); got != tt.want {
+				t.Errorf("Rect.Contains() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if got := r.Union(NewRect(Point{0, 8}, Point{1, 9})); got != (Rect{Point{0, 3}, Point{9, 9}}) {
+		t.Errorf("Rect.Union() = %v", got)
+	}
+	if got, ok := BoundingBox([]Point{{1, 5}, {-1, 2}, {3, 3}}); !ok || got != (Rect{Point{-1, 2}, Point{3, 5}}) {
+		t.Errorf("BoundingBox() = %v, %v", got, ok)
+	}
+	if _, ok := BoundingBox(nil); ok {
+		t.Errorf("BoundingBox() of no points should be false")
+	}
+}
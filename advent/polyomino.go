@@ -0,0 +1,149 @@
+package advent
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Polyomino is a shape made of square cells. It's always normalized, the cells are in row-major order and
+// shifted so the top and left of the shape are at 0, so two polyominoes with the same shape are Equal.
+type Polyomino struct {
+	cells []Point
+}
+
+// NewPolyomino makes a polyomino from its cells, in any order and anywhere on the plane
+func NewPolyomino(cells ...Point) Polyomino {
+	return Polyomino{cells: normalize(slices.Clone(cells))}
+}
+
+// normalize sorts the cells, removes duplicates and moves the bounding box to 0,0
+func normalize(cells []Point) []Point {
+	if len(cells) == 0 {
+		return nil
+	}
+	bounds, _ := BoundingBox(cells)
+	for i := range cells {
+		cells[i] = cells[i].Sub(bounds.Min)
+	}
+	slices.SortFunc(cells, compareRowMajor)
+	return slices.Compact(cells)
+}
+
+func compareRowMajor(a, b Point) int {
+	return cmp.Or(cmp.Compare(a.Y, b.Y), cmp.Compare(a.X, b.X))
+}
+
+// ParsePolyomino reads a shape drawn with # for cells and . for gaps, one line per row
+//
+//	###
+//	#..
+//	###
+func ParsePolyomino(lines []string) (Polyomino, error) {
+	var cells []Point
+	for y, line := range lines {
+		for x, r := range line {
+			switch r {
+			case '#':
+				cells = append(cells, Point{X: x, Y: y})
+			case '.':
+			default:
+				return Polyomino{}, fmt.Errorf("invalid polyomino cell %q at %d,%d", r, x, y)
+			}
+		}
+	}
+	if len(cells) == 0 {
+		return Polyomino{}, fmt.Errorf("polyomino has no cells")
+	}
+	return Polyomino{cells: normalize(cells)}, nil
+}
+
+// Cells returns the cells in row-major order
+func (p Polyomino) Cells() []Point {
+	return slices.Clone(p.cells)
+}
+
+// Len is the number of cells
+func (p Polyomino) Len() int {
+	return len(p.cells)
+}
+
+// Bounds is the bounding box, which always starts at 0,0
+func (p Polyomino) Bounds() Rect {
+	bounds, _ := BoundingBox(p.cells)
+	return bounds
+}
+
+// Width of the bounding box
+func (p Polyomino) Width() int {
+	if len(p.cells) == 0 {
+		return 0
+	}
+	return p.Bounds().Width()
+}
+
+// Height of the bounding box
+func (p Polyomino) Height() int {
+	if len(p.cells) == 0 {
+		return 0
+	}
+	return p.Bounds().Height()
+}
+
+// Equal is true if both polyominoes are the same shape in the same orientation
+func (p Polyomino) Equal(o Polyomino) bool {
+	return slices.Equal(p.cells, o.cells)
+}
+
+// Rotate turns the shape clockwise by quarterTurns, negative turns go counterclockwise
+func (p Polyomino) Rotate(quarterTurns int) Polyomino {
+	cells := make([]Point, len(p.cells))
+	for i, c := range p.cells {
+		cells[i] = c.Rotate(quarterTurns)
+	}
+	return Polyomino{cells: normalize(cells)}
+}
+
+// Flip mirrors the shape left to right
+func (p Polyomino) Flip() Polyomino {
+	cells := make([]Point, len(p.cells))
+	for i, c := range p.cells {
+		cells[i] = Point{X: -c.X, Y: c.Y}
+	}
+	return Polyomino{cells: normalize(cells)}
+}
+
+// Orientations returns every distinct way to rotate and flip the shape, starting with the shape itself.
+// Symmetric shapes have fewer than 8.
+func (p Polyomino) Orientations() []Polyomino {
+	var all []Polyomino
+	for _, shape := range []Polyomino{p, p.Flip()} {
+		for turns := range 4 {
+			o := shape.Rotate(turns)
+			if !slices.ContainsFunc(all, o.Equal) {
+				all = append(all, o)
+			}
+		}
+	}
+	return all
+}
+
+// Grid draws the shape into a grid the size of its bounding box
+func (p Polyomino) Grid() *Grid[bool] {
+	g := NewGrid[bool](p.Width(), p.Height())
+	for _, c := range p.cells {
+		g.Set(c, true)
+	}
+	return g
+}
+
+// String draws the shape in the same format ParsePolyomino reads
+func (p Polyomino) String() string {
+	return strings.TrimSuffix(p.Grid().Render(func(_ Point, on bool) string {
+		if on {
+			return "#"
+		}
+		return "."
+	}), "\n")
+}
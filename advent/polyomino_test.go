@@ -0,0 +1,102 @@
+package advent
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParsePolyomino(t *testing.T) {
+	tests := []struct {
+		name    string
+		lines   []string
+		want    []Point
+		wantErr bool
+	}{
+		{"T", []string{"###", ".#."}, []Point{{0, 0}, {1, 0}, {2, 0}, {1, 1}}, false},
+		{"padding is trimmed", []string{"....", "..#.", "..##"}, []Point{{0, 0}, {0, 1}, {1, 1}}, false},
+		{"ragged rows", []string{"#", "###"}, []Point{{0, 0}, {0, 1}, {1, 1}, {2, 1}}, false},
+		{"empty", []string{"...", "..."}, nil, true},
+		{"bad cell", []string{"#x#"}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePolyomino(tt.lines)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePolyomino() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !slices.Equal(got.Cells(), tt.want) {
+				t.Errorf("ParsePolyomino() = %v, want %v", got.Cells(), tt.want)
+			}
+		})
+	}
+}
+
+func TestPolyomino_Orientations(t *testing.T) {
+	tests := []struct {
+		name  string
+		piece []string
+		want  int
+	}{
+		{"monomino", []string{"#"}, 1},
+		{"square", []string{"##", "##"}, 1},
+		{"line", []string{"###"}, 2},
+		{"S", []string{".##", "##."}, 4},
+		{"T", []string{"###", ".#."}, 4},
+		{"L", []string{"#.", "#.", "##"}, 8},
+		{"plus", []string{".#.", "###", ".#."}, 1},
+		{"day 12 C", []string{"###", "#..", "###"}, 4},
+		{"F pentomino", []string{".##", "##.", ".#."}, 8},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := ParsePolyomino(tt.piece)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := p.Orientations()
+			if len(got) != tt.want {
+				t.Errorf("Orientations() = %v, want %d of them", got, tt.want)
+			}
+			if !got[0].Equal(p) {
+				t.Errorf("Orientations() starts with %v, want the shape itself", got[0])
+			}
+			for _, o := range got {
+				if o.Len() != p.Len() || o.Bounds().Min != (Point{}) {
+					t.Errorf("orientation %v isn't normalized", o)
+				}
+			}
+		})
+	}
+}
+
+func TestPolyomino_Transforms(t *testing.T) {
+	l, _ := ParsePolyomino([]string{"#.", "#.", "##"})
+
+	tests := []struct {
+		name string
+		got  Polyomino
+		want []string
+	}{
+		{"clockwise", l.Rotate(1), []string{"###", "#.."}},
+		{"half turn", l.Rotate(2), []string{"##", ".#", ".#"}},
+		{"counterclockwise", l.Rotate(-1), []string{"..#", "###"}},
+		{"full turn", l.Rotate(4), []string{"#.", "#.", "##"}},
+		{"flip", l.Flip(), []string{".#", ".#", "##"}},
+		{"flip twice", l.Flip().Flip(), []string{"#.", "#.", "##"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, want := tt.got.String(), strings.Join(tt.want, "\n"); got != want {
+				t.Errorf("got\n%s\nwant\n%s", got, want)
+			}
+		})
+	}
+
+	if l.Width() != 2 || l.Height() != 3 || l.Rotate(1).Width() != 3 {
+		t.Errorf("Width(), Height() = %d, %d, want 2, 3", l.Width(), l.Height())
+	}
+	if same := NewPolyomino(Point{5, 7}, Point{5, 8}, Point{5, 9}, Point{6, 9}, Point{5, 7}); !same.Equal(l) {
+		t.Errorf("NewPolyomino() = %v, want it normalized to %v", same, l)
+	}
+}
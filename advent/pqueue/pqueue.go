@@ -0,0 +1,81 @@
+// Package pqueue is a generic priority queue backed by a binary heap
+package pqueue
+
+// Queue pops items in priority order, lowest first according to less
+type Queue[T any] struct {
+	items []T
+	less  func(a, b T) bool
+}
+
+// New makes an empty queue. Pop returns the item for which less is true against every other item.
+func New[T any](less func(a, b T) bool) *Queue[T] {
+	return &Queue[T]{less: less}
+}
+
+// Len is the number of items in the queue
+func (q *Queue[T]) Len() int {
+	return len(q.items)
+}
+
+// Push adds v to the queue
+func (q *Queue[T]) Push(v T) {
+	q.items = append(q.items, v)
+	q.up(len(q.items) - 1)
+}
+
+// Pop removes and returns the lowest item, or false if the queue is empty
+func (q *Queue[T]) Pop() (T, bool) {
+	var zero T
+	if len(q.items) == 0 {
+		return zero, false
+	}
+
+	top := q.items[0]
+	last := len(q.items) - 1
+	q.items[0] = q.items[last]
+	q.items[last] = zero // don't hold on to popped items
+	q.items = q.items[:last]
+	q.down(0)
+	return top, true
+}
+
+// Peek returns the lowest item without removing it, or false if the queue is empty
+func (q *Queue[T]) Peek() (T, bool) {
+	if len(q.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return q.items[0], true
+}
+
+// up moves the item at i toward the root until its parent is lower
+func (q *Queue[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !q.less(q.items[i], q.items[parent]) {
+			return
+		}
+		q.items[i], q.items[parent] = q.items[parent], q.items[i]
+		i = parent
+	}
+}
+
+// down moves the item at i toward the leaves until both children are higher
+func (q *Queue[T]) down(i int) {
+	n := len(q.items)
+	for {
+		lowest := i
+		left, right := 2*i+1, 2*i+2
+		if left < n && q.less(q.items[left], q.items[lowest]) {
+			lowest = left
+		}
+		if right < n && q.less(q.items[right], q.items[lowest]) {
+			lowest = right
+		}
+		if lowest == i {
+			return
+		}
+		q.items[i], q.items[lowest] = q.items[lowest], q.items[i]
+		i = lowest
+	}
+}
@@ -0,0 +1,63 @@
+package pqueue_test
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/sirgwain/advent-of-code-2025/advent/pqueue"
+)
+
+func TestQueue(t *testing.T) {
+	q := pqueue.New(func(a, b int) bool { return a < b })
+	if _, ok := q.Pop(); ok {
+		t.Fatalf("Queue.Pop() of an empty queue should be false")
+	}
+
+	r := rand.New(rand.NewPCG(1, 2))
+	values := make([]int, 1000)
+	for i := range values {
+		values[i] = r.IntN(100) // plenty of duplicates
+		q.Push(values[i])
+	}
+	if q.Len() != len(values) {
+		t.Errorf("Queue.Len() = %d, want %d", q.Len(), len(values))
+	}
+
+	slices.Sort(values)
+	for i, want := range values {
+		if top, _ := q.Peek(); top != want {
+			t.Fatalf("Queue.Peek() %d = %d, want %d", i, top, want)
+		}
+		if got, ok := q.Pop(); !ok || got != want {
+			t.Fatalf("Queue.Pop() %d = %d, %v, want %d", i, got, ok, want)
+		}
+	}
+	if q.Len() != 0 {
+		t.Errorf("Queue.Len() = %d after popping everything", q.Len())
+	}
+}
+
+func TestQueue_Interleaved(t *testing.T) {
+	type item struct {
+		name     string
+		priority int
+	}
+	// highest priority first
+	q := pqueue.New(func(a, b item) bool { return a.priority > b.priority })
+	q.Push(item{"low", 1})
+	q.Push(item{"high", 10})
+	if got, _ := q.Pop(); got.name != "high" {
+		t.Errorf("Queue.Pop() = %v, want high", got)
+	}
+	q.Push(item{"mid", 5})
+	q.Push(item{"highest", 20})
+	var got []string
+	for q.Len() > 0 {
+		v, _ := q.Pop()
+		got = append(got, v.name)
+	}
+	if want := []string{"highest", "mid", "low"}; !slices.Equal(got, want) {
+		t.Errorf("Queue.Pop() order = %v, want %v", got, want)
+	}
+}
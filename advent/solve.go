@@ -0,0 +1,89 @@
+package advent
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+)
+
+// Solve runs a day on an input and returns its answers, for using the days from other programs. Days run quietly
+// unless the options say otherwise.
+//
+//	answer, err := advent.Solve(5, strings.NewReader(input))
+func Solve(day int, r io.Reader, opts ...Option) (Answer, error) {
+	d, err := NewDay(day)
+	if err != nil {
+		return Answer{}, err
+	}
+	options := NewRun(append([]Option{WithQuiet(true)}, opts...)...)
+	if err := d.Init(r, options); err != nil {
+		return Answer{}, err
+	}
+	return runDay(d, func(DayUpdate) {})
+}
+
+// SolveFS runs a day on the input at name in fsys, like an embedded example or a file in an archive
+func SolveFS(day int, fsys fs.FS, name string, opts ...Option) (Answer, error) {
+	file, err := fsys.Open(name)
+	if err != nil {
+		return Answer{}, fmt.Errorf("error opening input: %w", err)
+	}
+	defer file.Close()
+	return Solve(day, file, append([]Option{WithInputName(name)}, opts...)...)
+}
+
+// InitFile initializes a day with the input in a file, or stdin if the filename is -
+func InitFile(d Day, filename string, options *Options) error {
+	if filename == "-" {
+		return d.Init(os.Stdin, withInputName(options, "stdin"))
+	}
+	file, err := os.Open(filename)
+	if err != nil {
+		return fmt.Errorf("error opening file: %w", err)
+	}
+	defer file.Close()
+	return d.Init(file, withInputName(options, filename))
+}
+
+// InitFS initializes a day with the input at name in fsys
+func InitFS(d Day, fsys fs.FS, name string, options *Options) error {
+	file, err := fsys.Open(name)
+	if err != nil {
+		return fmt.Errorf("error opening input: %w", err)
+	}
+	defer file.Close()
+	return d.Init(file, withInputName(options, name))
+}
+
+// withInputName names the input in errors, unless the caller already named it. It names a copy, so callers
+// can reuse their options for other inputs.
+func withInputName(options *Options, name string) *Options {
+	o := *options
+	if o.InputName == "" {
+		o.InputName = name
+	}
+	return &o
+}
+
+// runDay runs a day to the end, passing each update to onUpdate, and returns the last answer
+func runDay(d Day, onUpdate func(DayUpdate)) (Answer, error) {
+	updates := make(chan DayUpdate, 16)
+	errCh := make(chan error, 1)
+
+	// Run the day in a goroutine
+	go func() {
+		err := d.Run(updates)
+		close(updates)
+
+		errCh <- err
+	}()
+
+	// Consume updates as they arrive
+	var answer Answer
+	for u := range updates {
+		onUpdate(u)
+		answer = u.Answer
+	}
+	return answer, <-errCh
+}
@@ -0,0 +1,124 @@
+package advent
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestSolve(t *testing.T) {
+	tests := []struct {
+		day   int
+		input string
+		want  Answer
+	}{
+		{1, "L68\nL30\nR48\nL5\nR60\nL55\nL1\nL99\nR14\nL82\n", Answer{Part1: 3, Part2: 6}},
+		{2, "11-22,95-115,998-1012,1188511880-1188511890,222220-222224,1698522-1698528,446443-446449,38593856-38593862,565653-565659,824824821-824824827,2121212118-2121212124\n", Answer{Part1: 1227775554, Part2: 4174379265}},
+		{3, "987654321111111\n811111111111119\n234234234234278\n818181911112111\n", Answer{Part1: 357, Part2: 3121910778619}},
+		{5, "3-5\n10-14\n16-20\n12-18\n\n1\n5\n8\n11\n17\n32\n", Answer{Part1: 3, Part2: 14}},
+		{6, "123 328  51 64 \n 45 64  387 23 \n  6 98  215 314\n*   +   *   +  \n", Answer{Part1: 4277556, Part2: 3263827}},
+		{9, strings.Join([]string{"7,1", "11,1", "11,7", "9,7", "9,5", "2,5", "2,3", "7,3"}, "\n"), Answer{Part1: 50, Part2: 24}},
+		{10, strings.Join(day10Example, "\n"), Answer{Part1: 7, Part2: 33}},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("day%d", tt.day), func(t *testing.T) {
+			got, err := Solve(tt.day, strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("Solve(%d) error = %v", tt.day, err)
+			}
+			if got != tt.want {
+				t.Errorf("Solve(%d) = %v, want %v", tt.day, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSolve_Errors(t *testing.T) {
+	if _, err := Solve(99, strings.NewReader("")); err == nil {
+		t.Errorf("Solve(99) succeeded, want an error for a missing day")
+	}
+
+	_, err := Solve(1, strings.NewReader("L68\nX5\n"), WithInputName("stdin"))
+	var perr *ParseError
+	if !errors.As(err, &perr) || perr.File != "stdin" || perr.Line != 2 {
+		t.Errorf("Solve(1) error = %v, want a ParseError on stdin line 2", err)
+	}
+}
+
+func TestSolveFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"examples/day8.txt": {Data: []byte(strings.Join(day8Example, "\n"))},
+		"examples/bad.txt":  {Data: []byte("1,2\n")},
+	}
+
+	got, err := SolveFS(8, fsys, "examples/day8.txt")
+	if want := (Answer{Part1: 40, Part2: 25272}); err != nil || got != want {
+		t.Errorf("SolveFS(8) = %v, %v, want %v", got, err, want)
+	}
+
+	_, err = SolveFS(8, fsys, "examples/bad.txt")
+	var perr *ParseError
+	if !errors.As(err, &perr) || perr.File != "examples/bad.txt" {
+		t.Errorf("SolveFS(8) error = %v, want a ParseError in examples/bad.txt", err)
+	}
+
+	if _, err := SolveFS(8, fsys, "examples/missing.txt"); err == nil {
+		t.Errorf("SolveFS(8) succeeded, want an error for a missing file")
+	}
+}
+
+func TestSolve_Implementations(t *testing.T) {
+	day9Input := strings.Join([]string{"7,1", "11,1", "11,7", "9,7", "9,5", "2,5", "2,3", "7,3"}, "\n")
+	day10Input := strings.Join(day10Example, "\n")
+	tests := []struct {
+		name    string
+		day     int
+		input   string
+		params  map[string]string
+		want    Answer
+		wantErr bool
+	}{
+		{"day9 mask", 9, day9Input, map[string]string{"validate": "mask"}, Answer{Part1: 50, Part2: 24}, false},
+		{"day9 rows", 9, day9Input, map[string]string{"validate": "rows"}, Answer{Part1: 50, Part2: 24}, false},
+		{"day9 unknown", 9, day9Input, map[string]string{"validate": "guess"}, Answer{}, true},
+		{"day10 ilp", 10, day10Input, map[string]string{"joltage": "ilp"}, Answer{Part1: 7, Part2: 33}, false},
+		{"day10 halving", 10, day10Input, map[string]string{"joltage": "halving"}, Answer{Part1: 7, Part2: 33}, false},
+		{"day10 unknown", 10, day10Input, map[string]string{"joltage": "guess"}, Answer{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Solve(tt.day, strings.NewReader(tt.input), WithParams(tt.params))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Solve(%d) error = %v, wantErr %v", tt.day, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Solve(%d) = %v, want %v", tt.day, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInitFS_ReusedOptions(t *testing.T) {
+	fsys := fstest.MapFS{
+		"a.txt": {Data: []byte("L68\nX5\n")},
+		"b.txt": {Data: []byte("L68\nR5\nX5\n")},
+	}
+
+	// each input is named in its own errors, the shared options aren't changed
+	options := NewRun(WithQuiet(true))
+	for _, tt := range []struct {
+		name string
+		line int
+	}{{"a.txt", 2}, {"b.txt", 3}} {
+		err := InitFS(&Day1{}, fsys, tt.name, options)
+		var perr *ParseError
+		if !errors.As(err, &perr) || perr.File != tt.name || perr.Line != tt.line {
+			t.Errorf("InitFS(%s) error = %v, want a ParseError on %s line %d", tt.name, err, tt.name, tt.line)
+		}
+	}
+	if options.InputName != "" {
+		t.Errorf("InitFS() set InputName = %q on the caller's options", options.InputName)
+	}
+}
@@ -0,0 +1,226 @@
+package advent
+
+import (
+	"cmp"
+	"iter"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/sirgwain/advent-of-code-2025/advent/braille"
+)
+
+// SparseGrid is a map backed 2D board for huge, mostly empty coordinate spaces.
+// Only cells that have been Set take up memory. Unset cells read as the zero value.
+type SparseGrid[T comparable] struct {
+	cells map[Point]T
+
+	// bounds grows as cells are set. Deleting a cell marks it stale so it's recomputed on the next read.
+	bounds      Rect
+	boundsStale bool
+}
+
+// NewSparseGrid makes an empty sparse grid
+func NewSparseGrid[T comparable]() *SparseGrid[T] {
+	return &SparseGrid[T]{cells: make(map[Point]T)}
+}
+
+// Len is the number of cells that have been set
+func (g *SparseGrid[T]) Len() int {
+	return len(g.cells)
+}
+
+// Bounds is the smallest Rect containing every set cell, or false if the grid is empty
+func (g *SparseGrid[T]) Bounds() (Rect, bool) {
+	if len(g.cells) == 0 {
+		return Rect{}, false
+	}
+	if g.boundsStale {
+		g.bounds, _ = BoundingBox(slices.Collect(maps.Keys(g.cells)))
+		g.boundsStale = false
+	}
+	return g.bounds, true
+}
+
+// Width is the width of the bounding box
+func (g *SparseGrid[T]) Width() int {
+	if b, ok := g.Bounds(); ok {
+		return b.Width()
+	}
+	return 0
+}
+
+// Height is the height of the bounding box
+func (g *SparseGrid[T]) Height() int {
+	if b, ok := g.Bounds(); ok {
+		return b.Height()
+	}
+	return 0
+}
+
+// InBounds is true if p is inside the bounding box of the set cells
+func (g *SparseGrid[T]) InBounds(p Point) bool {
+	b, ok := g.Bounds()
+	return ok && b.Contains(p)
+}
+
+// Get returns the value at p, or false if it has never been set
+func (g *SparseGrid[T]) Get(p Point) (T, bool) {
+	v, ok := g.cells[p]
+	return v, ok
+}
+
+// At returns the value at p or the zero value if it isn't set
+func (g *SparseGrid[T]) At(p Point) T {
+	return g.cells[p]
+}
+
+// Set sets the value at p, growing the bounds if needed. A sparse grid has no edges, so it always returns true.
+func (g *SparseGrid[T]) Set(p Point, v T) bool {
+	if len(g.cells) == 0 {
+		g.bounds = Rect{p, p}
+		g.boundsStale = false
+	} else if !g.boundsStale {
+		g.bounds = g.bounds.Union(Rect{p, p})
+	}
+	g.cells[p] = v
+	return true
+}
+
+// Delete clears the cell at p
+func (g *SparseGrid[T]) Delete(p Point) {
+	if _, ok := g.cells[p]; !ok {
+		return
+	}
+	delete(g.cells, p)
+	g.boundsStale = true
+}
+
+// All iterates over every set cell, left to right, top to bottom
+func (g *SparseGrid[T]) All() iter.Seq2[Point, T] {
+	return func(yield func(Point, T) bool) {
+		points := slices.SortedFunc(maps.Keys(g.cells), func(a, b Point) int {
+			return cmp.Or(cmp.Compare(a.Y, b.Y), cmp.Compare(a.X, b.X))
+		})
+		for _, p := range points {
+			if !yield(p, g.cells[p]) {
+				return
+			}
+		}
+	}
+}
+
+// Neighbors iterates over the set cells next to p in each direction
+func (g *SparseGrid[T]) Neighbors(p Point, directions []Direction) iter.Seq2[Point, T] {
+	return func(yield func(Point, T) bool) {
+		for n := range p.Neighbors(directions) {
+			v, ok := g.cells[n]
+			if !ok {
+				continue
+			}
+			if !yield(n, v) {
+				return
+			}
+		}
+	}
+}
+
+// Neighbors4 iterates over the set up, right, down and left neighbors of p
+func (g *SparseGrid[T]) Neighbors4(p Point) iter.Seq2[Point, T] {
+	return g.Neighbors(p, CardinalDirections)
+}
+
+// Neighbors8 iterates over all set neighbors of p, including diagonals
+func (g *SparseGrid[T]) Neighbors8(p Point) iter.Seq2[Point, T] {
+	return g.Neighbors(p, AdjacentDirections)
+}
+
+// Find returns the first position of v, scanning left to right, top to bottom
+func (g *SparseGrid[T]) Find(v T) (Point, bool) {
+	for p, c := range g.All() {
+		if c == v {
+			return p, true
+		}
+	}
+	return Point{}, false
+}
+
+// FindAll returns every position of v, or false if there are none
+func (g *SparseGrid[T]) FindAll(v T) ([]Point, bool) {
+	var points []Point
+	for p, c := range g.All() {
+		if c == v {
+			points = append(points, p)
+		}
+	}
+	return points, len(points) > 0
+}
+
+// Count counts the set cells equal to v
+func (g *SparseGrid[T]) Count(v T) int {
+	count := 0
+	for _, c := range g.cells {
+		if c == v {
+			count++
+		}
+	}
+	return count
+}
+
+// Clone makes a deep copy of the grid
+func (g *SparseGrid[T]) Clone() *SparseGrid[T] {
+	return &SparseGrid[T]{
+		cells:       maps.Clone(g.cells),
+		bounds:      g.bounds,
+		boundsStale: g.boundsStale,
+	}
+}
+
+// Render draws every cell in the bounding box, one line per row, using cell to render each cell.
+// Unset cells are passed to cell as the zero value.
+func (g *SparseGrid[T]) Render(cell func(p Point, v T) string) string {
+	b, ok := g.Bounds()
+	if !ok {
+		return ""
+	}
+	var sb strings.Builder
+	for y := b.Min.Y; y <= b.Max.Y; y++ {
+		for x := b.Min.X; x <= b.Max.X; x++ {
+			p := Point{x, y}
+			sb.WriteString(cell(p, g.cells[p]))
+		}
+		sb.WriteRune('\n')
+	}
+	return sb.String()
+}
+
+// RenderBraille draws the grid scaled down to fit in maxWidth x maxHeight characters, with each
+// character showing 2x4 pixels. A pixel is on if on returns true for any cell that lands in it.
+// Grids that already fit are drawn one cell per pixel.
+func (g *SparseGrid[T]) RenderBraille(maxWidth, maxHeight int, on func(p Point, v T) bool) string {
+	return braille.Render(g.Pixels(maxWidth, maxHeight, on))
+}
+
+// Pixels scales the grid down to the pixels RenderBraille would draw, for rendering them some
+// other way, like with a Theme
+func (g *SparseGrid[T]) Pixels(maxWidth, maxHeight int, on func(p Point, v T) bool) [][]byte {
+	b, ok := g.Bounds()
+	if !ok || maxWidth <= 0 || maxHeight <= 0 {
+		return nil
+	}
+
+	// scale both axes by the same amount so shapes aren't stretched
+	pixelsWide, pixelsHigh := maxWidth*2, maxHeight*4
+	scale := max(1,
+		(b.Width()+pixelsWide-1)/pixelsWide,
+		(b.Height()+pixelsHigh-1)/pixelsHigh,
+	)
+
+	pixels := MakeBoard[byte]((b.Width()+scale-1)/scale, (b.Height()+scale-1)/scale)
+	for p, v := range g.cells {
+		if on(p, v) {
+			pixels[(p.Y-b.Min.Y)/scale][(p.X-b.Min.X)/scale] = 1
+		}
+	}
+	return pixels
+}
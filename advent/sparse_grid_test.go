@@ -0,0 +1,96 @@
+package advent
+
+import (
+	"maps"
+	"slices"
+	"testing"
+)
+
+func TestSparseGrid_Bounds(t *testing.T) {
+	g := NewSparseGrid[rune]()
+	if _, ok := g.Bounds(); ok {
+		t.Errorf("SparseGrid.Bounds() of an empty grid should be false")
+	}
+
+	g.Set(Point{100000, -5}, 'a')
+	g.Set(Point{-3, 40000}, 'b')
+	g.Set(Point{7, 7}, 'c')
+	if b, ok := g.Bounds(); !ok || b != (Rect{Point{-3, -5}, Point{100000, 40000}}) {
+		t.Errorf("SparseGrid.Bounds() = %v, %v", b, ok)
+	}
+	if g.Len() != 3 {
+		t.Errorf("SparseGrid.Len() = %v, want 3", g.Len())
+	}
+
+	// deleting an edge cell shrinks the bounds
+	g.Delete(Point{100000, -5})
+	if b, ok := g.Bounds(); !ok || b != (Rect{Point{-3, 7}, Point{7, 40000}}) {
+		t.Errorf("SparseGrid.Bounds() after Delete = %v, %v", b, ok)
+	}
+	g.Set(Point{8, 0}, 'd')
+	if b, _ := g.Bounds(); b != (Rect{Point{-3, 0}, Point{8, 40000}}) {
+		t.Errorf("SparseGrid.Bounds() after Set = %v", b)
+	}
+}
+
+func TestSparseGrid_Get(t *testing.T) {
+	g := NewSparseGrid[int]()
+	g.Set(Point{2, 3}, 5)
+
+	if v, ok := g.Get(Point{2, 3}); !ok || v != 5 {
+		t.Errorf("SparseGrid.Get() = %v, %v, want 5, true", v, ok)
+	}
+	if v, ok := g.Get(Point{3, 2}); ok || v != 0 {
+		t.Errorf("SparseGrid.Get() of unset cell = %v, %v, want 0, false", v, ok)
+	}
+	if got := g.At(Point{-1000, 1000}); got != 0 {
+		t.Errorf("SparseGrid.At() of unset cell = %v, want 0", got)
+	}
+}
+
+func TestSparseGrid_Find(t *testing.T) {
+	g := NewSparseGrid[rune]()
+	g.Set(Point{5, 1}, 'S')
+	g.Set(Point{9, 0}, 'S')
+	g.Set(Point{0, 1}, 'S')
+	g.Set(Point{1, 1}, '.')
+
+	if p, ok := g.Find('S'); !ok || p != (Point{9, 0}) {
+		t.Errorf("SparseGrid.Find() = %v, %v, want 9, 0", p, ok)
+	}
+	if points, ok := g.FindAll('S'); !ok || !slices.Equal(points, []Point{{9, 0}, {0, 1}, {5, 1}}) {
+		t.Errorf("SparseGrid.FindAll() = %v, %v", points, ok)
+	}
+	if _, ok := g.Find('x'); ok {
+		t.Errorf("SparseGrid.Find() found a missing value")
+	}
+	if got := maps.Collect(g.Neighbors4(Point{1, 1})); len(got) != 1 || got[Point{0, 1}] != 'S' {
+		t.Errorf("SparseGrid.Neighbors4() = %v", got)
+	}
+}
+
+func TestSparseGrid_Render(t *testing.T) {
+	g := NewSparseGrid[rune]()
+	g.Set(Point{10, 20}, '#')
+	g.Set(Point{12, 21}, '#')
+
+	got := g.Render(func(p Point, r rune) string {
+		if r == 0 {
+			return "."
+		}
+		return string(r)
+	})
+	if want := "#..\n..#\n"; got != want {
+		t.Errorf("SparseGrid.Render() = %q, want %q", got, want)
+	}
+
+	// a line 10000 cells wide scales down to a single row of 4 braille characters
+	line := NewSparseGrid[bool]()
+	for x := range 10000 {
+		line.Set(Point{x, 0}, true)
+	}
+	got = line.RenderBraille(4, 4, func(p Point, v bool) bool { return v })
+	if want := "⠉⠉⠉⠉\n"; got != want {
+		t.Errorf("SparseGrid.RenderBraille() = %q, want %q", got, want)
+	}
+}
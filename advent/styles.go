@@ -1,23 +1,187 @@
 package advent
 
 import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
 	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"github.com/sirgwain/advent-of-code-2025/advent/braille"
 	"github.com/sirgwain/advent-of-code-2025/advent/color"
 )
 
-var (
+// Theme is a set of semantic styles days render with. A zero Theme renders
+// everything as plain text.
+type Theme struct {
+	Name string
+
 	// the style of the solution text
-	solutionStyle        = lipgloss.NewStyle().Foreground(color.Aquamarine86)
-	StepStyle            = lipgloss.NewStyle().Foreground(color.LightYellow11)
-	data1Style           = lipgloss.NewStyle().Foreground(color.LightCobaltBlue110)
-	data2Style           = lipgloss.NewStyle().Foreground(color.Coral209)
-	correctResultStyle   = lipgloss.NewStyle().Foreground(color.StrongLimeGreen40)
-	incorrectResultStyle = lipgloss.NewStyle().Foreground(color.LightRed9)
+	Solution  lipgloss.Style
+	Step      lipgloss.Style
+	Data1     lipgloss.Style
+	Data2     lipgloss.Style
+	Correct   lipgloss.Style
+	Incorrect lipgloss.Style
 
 	// some common styles
-	wallStyle    = lipgloss.NewStyle().Foreground(color.BlazeOrange202)
-	visitedStyle = lipgloss.NewStyle().Foreground(color.Aqua14).Background(color.FreshEggplant90)
+	Wall         lipgloss.Style
+	Visited      lipgloss.Style
+	Highlight    lipgloss.Style
+	Box          lipgloss.Style
+	BoxHighlight lipgloss.Style
+	On           lipgloss.Style
+	Off          lipgloss.Style
+
+	// Accent is used for borders in the tui
+	Accent lipgloss.TerminalColor
 
-	boxStyle          = lipgloss.NewStyle().Foreground(lipgloss.Color(color.BrightGreen82))
-	boxHighlightStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(color.BrightGreen82)).Background(color.BlazeOrange202)
+	// ASCII themes avoid unicode glyphs
+	ASCII bool
+}
+
+const (
+	ThemeDark         = "dark"
+	ThemeLight        = "light"
+	ThemeHighContrast = "high-contrast"
+	ThemeNoColor      = "no-color"
+	ThemeASCII        = "ascii"
 )
+
+var themes = map[string]Theme{
+	ThemeDark: {
+		Name:         ThemeDark,
+		Solution:     lipgloss.NewStyle().Foreground(color.Aquamarine86),
+		Step:         lipgloss.NewStyle().Foreground(color.LightYellow11),
+		Data1:        lipgloss.NewStyle().Foreground(color.LightCobaltBlue110),
+		Data2:        lipgloss.NewStyle().Foreground(color.Coral209),
+		Correct:      lipgloss.NewStyle().Foreground(color.StrongLimeGreen40),
+		Incorrect:    lipgloss.NewStyle().Foreground(color.LightRed9),
+		Wall:         lipgloss.NewStyle().Foreground(color.BlazeOrange202),
+		Visited:      lipgloss.NewStyle().Foreground(color.Aqua14).Background(color.FreshEggplant90),
+		Highlight:    lipgloss.NewStyle().Foreground(color.BloodRed52).Background(color.VioletsAreBlue105),
+		Box:          lipgloss.NewStyle().Foreground(color.BrightGreen82),
+		BoxHighlight: lipgloss.NewStyle().Foreground(color.BrightGreen82).Background(color.BlazeOrange202),
+		On:           lipgloss.NewStyle().Foreground(color.BrightGreen82),
+		Off:          lipgloss.NewStyle().Foreground(color.Gray8),
+		Accent:       color.CornflowerBlue63,
+	},
+	ThemeLight: {
+		Name:         ThemeLight,
+		Solution:     lipgloss.NewStyle().Foreground(color.Teal30),
+		Step:         lipgloss.NewStyle().Foreground(color.DarkOrangeBrownTone130),
+		Data1:        lipgloss.NewStyle().Foreground(color.Endeavour25),
+		Data2:        lipgloss.NewStyle().Foreground(color.StrongOrange166),
+		Correct:      lipgloss.NewStyle().Foreground(color.Ao28),
+		Incorrect:    lipgloss.NewStyle().Foreground(color.GuardsmanRed160),
+		Wall:         lipgloss.NewStyle().Foreground(color.Brown94),
+		Visited:      lipgloss.NewStyle().Foreground(color.LightWhite231).Background(color.MetallicViolet54),
+		Highlight:    lipgloss.NewStyle().Foreground(color.LightWhite231).Background(color.DarkPink125),
+		Box:          lipgloss.NewStyle().Foreground(color.Camarone22),
+		BoxHighlight: lipgloss.NewStyle().Foreground(color.LightWhite231).Background(color.StrongOrange166),
+		On:           lipgloss.NewStyle().Foreground(color.Ao28),
+		Off:          lipgloss.NewStyle().Foreground(color.Silver250),
+		Accent:       color.DeepCerulean31,
+	},
+	ThemeHighContrast: {
+		Name:         ThemeHighContrast,
+		Solution:     lipgloss.NewStyle().Foreground(color.LightWhite15).Bold(true),
+		Step:         lipgloss.NewStyle().Foreground(color.LightYellow11).Bold(true),
+		Data1:        lipgloss.NewStyle().Foreground(color.Aqua14),
+		Data2:        lipgloss.NewStyle().Foreground(color.LightYellow11),
+		Correct:      lipgloss.NewStyle().Foreground(color.ElectricGreen10).Bold(true),
+		Incorrect:    lipgloss.NewStyle().Foreground(color.LightWhite15).Background(color.LightRed196).Bold(true),
+		Wall:         lipgloss.NewStyle().Foreground(color.LightWhite15).Bold(true),
+		Visited:      lipgloss.NewStyle().Foreground(color.Black0).Background(color.Aqua14),
+		Highlight:    lipgloss.NewStyle().Foreground(color.Black0).Background(color.LightYellow11),
+		Box:          lipgloss.NewStyle().Foreground(color.ElectricGreen10).Bold(true),
+		BoxHighlight: lipgloss.NewStyle().Foreground(color.Black0).Background(color.ElectricGreen10),
+		On:           lipgloss.NewStyle().Foreground(color.LightWhite15).Bold(true),
+		Off:          lipgloss.NewStyle().Foreground(color.Gray244),
+		Accent:       color.LightWhite15,
+	},
+	ThemeNoColor: {
+		Name:         ThemeNoColor,
+		Visited:      lipgloss.NewStyle().Reverse(true),
+		Highlight:    lipgloss.NewStyle().Reverse(true),
+		BoxHighlight: lipgloss.NewStyle().Reverse(true),
+		Accent:       lipgloss.NoColor{},
+	},
+	ThemeASCII: {
+		Name:   ThemeASCII,
+		Accent: lipgloss.NoColor{},
+		ASCII:  true,
+	},
+}
+
+// ThemeNames returns the names of the built-in themes
+func ThemeNames() []string {
+	names := make([]string, 0, len(themes))
+	for name := range themes {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// ThemeByName looks up a built-in theme, falling back to plainer themes when the
+// terminal can't render color or unicode. Color and glyphs fall back separately, so
+// a color terminal without a UTF-8 locale keeps the theme's colors.
+func ThemeByName(name string) (Theme, error) {
+	if name == "" {
+		name = ThemeDark
+	}
+	t, ok := themes[name]
+	if !ok {
+		return Theme{}, fmt.Errorf("unknown theme %q, expected one of %s", name, strings.Join(ThemeNames(), ", "))
+	}
+
+	if lipgloss.ColorProfile() == termenv.Ascii {
+		// NO_COLOR or output is piped
+		ascii := t.ASCII
+		t = themes[ThemeNoColor]
+		t.ASCII = ascii
+	}
+	if !unicodeSupported() {
+		t.ASCII = true
+	}
+	return t, nil
+}
+
+// Glyph returns the unicode glyph, or the ascii fallback for ASCII themes
+func (t Theme) Glyph(unicode, ascii string) string {
+	if t.ASCII {
+		return ascii
+	}
+	return unicode
+}
+
+// Braille draws a pixel grid as braille characters, 2x4 pixels each. ASCII themes
+// shade each character instead, darker the more pixels are on.
+func (t Theme) Braille(grid [][]byte) string {
+	return braille.RenderFunc(grid, func(_, _ int, r rune, dots [8]byte) string {
+		return t.Glyph(string(r), string(braille.Shade(dots)))
+	})
+}
+
+// BrailleDensity is like Braille, but styles each character by how many of its pixels are on
+func (t Theme) BrailleDensity(grid [][]byte) string {
+	// from sparse to dense
+	styles := []lipgloss.Style{t.Off, t.Data1, t.Data1, t.Correct, t.Correct, t.Step, t.Step, t.Incorrect, t.Incorrect}
+	return braille.RenderFunc(grid, func(_, _ int, r rune, dots [8]byte) string {
+		return styles[braille.DotCount(dots)].Render(t.Glyph(string(r), string(braille.Shade(dots))))
+	})
+}
+
+// unicodeSupported checks the locale for a UTF-8 charset
+func unicodeSupported() bool {
+	for _, env := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if v := os.Getenv(env); v != "" {
+			v = strings.ToUpper(v)
+			return strings.Contains(v, "UTF-8") || strings.Contains(v, "UTF8")
+		}
+	}
+	// no locale set, most terminals are utf8 these days
+	return os.Getenv("TERM") != "linux"
+}
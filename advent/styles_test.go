@@ -0,0 +1,69 @@
+package advent
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+func TestThemeByName_Fallbacks(t *testing.T) {
+	tests := []struct {
+		name      string
+		theme     string
+		profile   termenv.Profile
+		locale    string
+		wantName  string
+		wantASCII bool
+	}{
+		{"color and unicode", ThemeLight, termenv.ANSI256, "en_US.UTF-8", ThemeLight, false},
+		{"color without unicode keeps the colors", ThemeLight, termenv.ANSI256, "C", ThemeLight, true},
+		{"no color", ThemeLight, termenv.Ascii, "en_US.UTF-8", ThemeNoColor, false},
+		{"no color or unicode", ThemeLight, termenv.Ascii, "C", ThemeNoColor, true},
+		{"ascii without color stays ascii", ThemeASCII, termenv.Ascii, "en_US.UTF-8", ThemeNoColor, true},
+	}
+
+	profile := lipgloss.ColorProfile()
+	t.Cleanup(func() { lipgloss.SetColorProfile(profile) })
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lipgloss.SetColorProfile(tt.profile)
+			t.Setenv("LC_ALL", tt.locale)
+
+			got, err := ThemeByName(tt.theme)
+			if err != nil {
+				t.Fatalf("ThemeByName(%q) error = %v", tt.theme, err)
+			}
+			if got.Name != tt.wantName || got.ASCII != tt.wantASCII {
+				t.Errorf("ThemeByName(%q) = %s ascii: %v, want %s ascii: %v", tt.theme, got.Name, got.ASCII, tt.wantName, tt.wantASCII)
+			}
+			if tt.wantName == ThemeLight && got.Solution.GetForeground() != themes[ThemeLight].Solution.GetForeground() {
+				t.Errorf("ThemeByName(%q) dropped the theme's colors", tt.theme)
+			}
+		})
+	}
+}
+
+func TestTheme_Braille(t *testing.T) {
+	grid := [][]byte{
+		{1, 1, 1, 0},
+		{1, 1, 0, 0},
+		{1, 1, 0, 0},
+		{1, 1, 0, 0},
+	}
+
+	unicode := Theme{}
+	if got, want := unicode.Braille(grid), "⣿⠁\n"; got != want {
+		t.Errorf("Braille() = %q, want %q", got, want)
+	}
+
+	ascii := Theme{ASCII: true}
+	if got, want := ascii.Braille(grid), "#.\n"; got != want {
+		t.Errorf("Braille() = %q, want %q", got, want)
+	}
+	// a zero theme has no styles, so the density shading is plain too
+	if got, want := ascii.BrailleDensity(grid), "#.\n"; got != want {
+		t.Errorf("BrailleDensity() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,132 @@
+// Package unionfind is a generic disjoint set, for tracking which items are connected as they are joined together
+package unionfind
+
+import (
+	"cmp"
+	"slices"
+)
+
+// DisjointSet groups items into components. Joining two components and finding an item's component are
+// both nearly constant time, using union by size and path compression.
+type DisjointSet[T comparable] struct {
+	index  map[T]int
+	items  []T
+	parent []int
+	size   []int
+	count  int
+}
+
+// New makes a set with each item in its own component
+func New[T comparable](items ...T) *DisjointSet[T] {
+	s := &DisjointSet[T]{index: make(map[T]int, len(items))}
+	for _, item := range items {
+		s.Add(item)
+	}
+	return s
+}
+
+// Add puts x in its own component. It returns false if x was already in the set.
+func (s *DisjointSet[T]) Add(x T) bool {
+	if _, ok := s.index[x]; ok {
+		return false
+	}
+	s.index[x] = len(s.items)
+	s.items = append(s.items, x)
+	s.parent = append(s.parent, len(s.parent))
+	s.size = append(s.size, 1)
+	s.count++
+	return true
+}
+
+// Len is the number of items in the set
+func (s *DisjointSet[T]) Len() int {
+	return len(s.items)
+}
+
+// Count is the number of components
+func (s *DisjointSet[T]) Count() int {
+	return s.count
+}
+
+// root finds the index of the root of i's component, pointing everything on the way directly at it
+func (s *DisjointSet[T]) root(i int) int {
+	r := i
+	for s.parent[r] != r {
+		r = s.parent[r]
+	}
+	for s.parent[i] != r {
+		s.parent[i], i = r, s.parent[i]
+	}
+	return r
+}
+
+// indexOf returns the index of x, adding it if it's new
+func (s *DisjointSet[T]) indexOf(x T) int {
+	s.Add(x)
+	return s.index[x]
+}
+
+// Find returns the item that represents x's component. Items not in the set are added first.
+func (s *DisjointSet[T]) Find(x T) T {
+	return s.items[s.root(s.indexOf(x))]
+}
+
+// Union joins the components of a and b. It returns false if they were already connected.
+// Items not in the set are added first.
+func (s *DisjointSet[T]) Union(a, b T) bool {
+	ra, rb := s.root(s.indexOf(a)), s.root(s.indexOf(b))
+	if ra == rb {
+		return false
+	}
+	// hang the smaller tree off the larger one to keep the trees shallow
+	if s.size[ra] < s.size[rb] {
+		ra, rb = rb, ra
+	}
+	s.parent[rb] = ra
+	s.size[ra] += s.size[rb]
+	s.count--
+	return true
+}
+
+// Connected is true if a and b are in the same component
+func (s *DisjointSet[T]) Connected(a, b T) bool {
+	return s.Find(a) == s.Find(b)
+}
+
+// Size is the number of items in x's component
+func (s *DisjointSet[T]) Size(x T) int {
+	return s.size[s.root(s.indexOf(x))]
+}
+
+// Sizes returns the size of every component, largest first
+func (s *DisjointSet[T]) Sizes() []int {
+	var sizes []int
+	for i := range s.items {
+		if s.parent[i] == i {
+			sizes = append(sizes, s.size[i])
+		}
+	}
+	slices.SortFunc(sizes, func(a, b int) int { return cmp.Compare(b, a) })
+	return sizes
+}
+
+// Components returns the items in each component, largest component first. Items within a
+// component are in the order they were added.
+func (s *DisjointSet[T]) Components() [][]T {
+	byRoot := map[int][]T{}
+	var roots []int
+	for i, item := range s.items {
+		r := s.root(i)
+		if _, ok := byRoot[r]; !ok {
+			roots = append(roots, r)
+		}
+		byRoot[r] = append(byRoot[r], item)
+	}
+
+	components := make([][]T, len(roots))
+	for i, r := range roots {
+		components[i] = byRoot[r]
+	}
+	slices.SortStableFunc(components, func(a, b []T) int { return cmp.Compare(len(b), len(a)) })
+	return components
+}
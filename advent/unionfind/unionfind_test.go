@@ -0,0 +1,64 @@
+package unionfind_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/sirgwain/advent-of-code-2025/advent/unionfind"
+)
+
+func TestDisjointSet(t *testing.T) {
+	s := unionfind.New("a", "b", "c", "d", "e", "f")
+	if s.Count() != 6 || s.Len() != 6 {
+		t.Fatalf("New() = %d components, %d items, want 6, 6", s.Count(), s.Len())
+	}
+
+	tests := []struct {
+		a, b       string
+		wantMerged bool
+		wantCount  int
+	}{
+		{"a", "b", true, 5},
+		{"c", "d", true, 4},
+		{"b", "a", false, 4},
+		{"a", "d", true, 3},
+		{"b", "c", false, 3},
+		{"g", "e", true, 3}, // g is added
+	}
+	for _, tt := range tests {
+		if got := s.Union(tt.a, tt.b); got != tt.wantMerged {
+			t.Errorf("Union(%s, %s) = %v, want %v", tt.a, tt.b, got, tt.wantMerged)
+		}
+		if got := s.Count(); got != tt.wantCount {
+			t.Errorf("Union(%s, %s) left %d components, want %d", tt.a, tt.b, got, tt.wantCount)
+		}
+	}
+
+	if !s.Connected("b", "c") || s.Connected("a", "e") {
+		t.Errorf("Connected() is wrong")
+	}
+	if got := s.Size("d"); got != 4 {
+		t.Errorf("Size(d) = %d, want 4", got)
+	}
+	if got := s.Sizes(); !slices.Equal(got, []int{4, 2, 1}) {
+		t.Errorf("Sizes() = %v, want [4 2 1]", got)
+	}
+	want := [][]string{{"a", "b", "c", "d"}, {"e", "g"}, {"f"}}
+	if got := s.Components(); !slices.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("Components() = %v, want %v", got, want)
+	}
+	if s.Find("a") != s.Find("d") || s.Find("a") == s.Find("e") {
+		t.Errorf("Find() doesn't agree with Connected()")
+	}
+}
+
+func TestDisjointSet_Chain(t *testing.T) {
+	// a long chain joined from the end, which builds a deep tree without union by size
+	s := unionfind.New[int]()
+	for i := range 10000 {
+		s.Union(i, i+1)
+	}
+	if s.Count() != 1 || s.Size(0) != 10001 {
+		t.Errorf("chain = %d components, size %d, want 1, 10001", s.Count(), s.Size(0))
+	}
+}
@@ -2,104 +2,267 @@ package advent
 
 import (
 	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/sirgwain/advent-of-code-2025/tui"
 )
 
 type DayUpdate struct {
 	View     string
 	Solution string
+	Answer   Answer
 	Done     bool
 }
 
+// Answer holds the solutions to both parts of a day
+type Answer struct {
+	Part1 int64
+	Part2 int64
+}
+
 type Day interface {
 	Day() int
-	Init(filename string, options *Options) error
+	// Init reads the puzzle input and sets up the day to run. Bad input is a *ParseError.
+	Init(r io.Reader, options *Options) error
 	Run(updates chan<- DayUpdate) error
 }
 
+// KeyBinding is a day specific key binding and the handler to run when it's pressed
+type KeyBinding struct {
+	key.Binding
+	Handle func()
+}
+
+// KeyBinder is implemented by days that add their own key bindings to the visualizer
+type KeyBinder interface {
+	KeyBindings() []KeyBinding
+	// View renders the day's current view, it is called after a key is handled once the day is done running
+	View() string
+}
+
 func Run(d Day, filename string, opts ...Option) error {
 	options := NewRun(opts...)
-	if err := d.Init(filename, options); err != nil {
+	if err := InitFile(d, filename, options); err != nil {
 		return err
 	}
 
-	updates := make(chan DayUpdate, 16)
-	errCh := make(chan error, 1)
-
-	// Run the day in a goroutine
-	go func() {
-		err := d.Run(updates)
-		close(updates)
-
-		errCh <- err
-	}()
-
-	// Consume updates as they arrive
-	for u := range updates {
+	// print updates as they arrive
+	answer, err := runDay(d, func(u DayUpdate) {
 		fmt.Printf("%s %s\n", u.View, u.Solution)
+	})
+	if err != nil {
+		return err
 	}
 
-	// Return the error from Run
-	return <-errCh
+	if options.Copy {
+		return tui.CopyAnswers(os.Stderr, answer.Part1, answer.Part2)
+	}
+	return nil
 }
 
 func RunVisual(d Day, filename string, opts ...Option) error {
-	p := tui.NewViewportProgram(tui.NewModel(fmt.Sprintf("Day %d", d.Day())))
 	options := NewRun(opts...)
-
-	if err := d.Init(filename, options); err != nil {
+	if err := InitFile(d, filename, options); err != nil {
 		return err
 	}
 
-	updates := make(chan DayUpdate, 16) // buffered so Day isn’t blocked by UI speed
-	errCh := make(chan error, 1)
+	playback := tui.NewPlayback(time.Millisecond * time.Duration(options.Delay))
+	run := startVisualRun(d)
+	model := newVisualModel(fmt.Sprintf("Day %d", d.Day()), options).
+		WithPlayback(playback).
+		WithBindings(run.bindings(sendToModel)...)
+
+	p := tui.NewViewportProgram(model)
+	go run.feed(p, playback, sendToModel)
+
+	_, err := p.Run()
+	playback.Stop()
+	if err != nil {
+		return fmt.Errorf("could not start program: %v", err)
+	}
+
+	last := run.lastUpdate()
+	fmt.Printf("%s\n%s\n", last.View, last.Solution)
+
+	return run.err()
+}
+
+// ComparePane is one side of RunCompare, a day run on an input with its own options
+type ComparePane struct {
+	Day      Day
+	Filename string
+	Options  []Option
+}
+
+// label names a pane by its input and params, so two runs of the same input can be told apart
+func (p ComparePane) label(options *Options) string {
+	label := p.Filename
+	for _, key := range slices.Sorted(maps.Keys(options.Params)) {
+		label += fmt.Sprintf(" %s=%s", key, options.Params[key])
+	}
+	return label
+}
+
+// RunCompare runs two days side by side, i.e. the example and the real input, or one input with params
+// that pick a different implementation. opts apply to both panes, then each pane's own Options.
+func RunCompare(left, right ComparePane, opts ...Option) error {
+	panes := [2]ComparePane{left, right}
+	var options [2]*Options
+	for i, pane := range panes {
+		// each day gets its own options so they don't share state
+		options[i] = NewRun(append(slices.Clone(opts), pane.Options...)...)
+		if err := InitFile(pane.Day, pane.Filename, options[i]); err != nil {
+			return err
+		}
+	}
+
+	playback := tui.NewPlayback(time.Millisecond * time.Duration(options[0].Delay))
+	var runs [2]*visualRun
+	var labels [2]string
+	var models [2]tui.Model
+	for i, pane := range panes {
+		runs[i] = startVisualRun(pane.Day)
+		labels[i] = pane.label(options[i])
+		models[i] = newVisualModel(fmt.Sprintf("Day %d: %s", pane.Day.Day(), labels[i]), options[i]).
+			WithBindings(runs[i].bindings(sendToPane(i))...)
+	}
+
+	p := tui.NewSplitProgram(tui.NewSplitModel(models[0], models[1], playback))
+	for i, run := range runs {
+		go run.feed(p, playback, sendToPane(i))
+	}
+
+	_, err := p.Run()
+	playback.Stop()
+	if err != nil {
+		return fmt.Errorf("could not start program: %v", err)
+	}
+
+	for i, run := range runs {
+		fmt.Printf("%s: %s\n", labels[i], run.lastUpdate().Solution)
+	}
+
+	for _, run := range runs {
+		if err := run.err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newVisualModel(title string, options *Options) tui.Model {
+	return tui.NewModel(title).
+		WithAccent(options.Theme.Accent).
+		WithASCII(options.Theme.ASCII)
+}
+
+// sendToModel sends messages to a single model program
+func sendToModel(msg tea.Msg) tea.Msg {
+	return msg
+}
+
+// sendToPane sends messages to one pane of a split program
+func sendToPane(pane int) func(tea.Msg) tea.Msg {
+	return func(msg tea.Msg) tea.Msg {
+		return tui.ToPane(pane, msg)
+	}
+}
+
+// visualRun runs a day in the background and feeds its updates to the tui
+type visualRun struct {
+	d       Day
+	updates chan DayUpdate
+	errCh   chan error
+
+	// finished is set once the day is done sending updates so
+	// key bindings can re-render the view themselves
+	finished atomic.Bool
+
+	mu   sync.Mutex
+	last DayUpdate
+}
+
+func startVisualRun(d Day) *visualRun {
+	run := &visualRun{
+		d:       d,
+		updates: make(chan DayUpdate, 16), // buffered so Day isn’t blocked by UI speed
+		errCh:   make(chan error, 1),
+	}
 
 	// start the day's work
 	go func() {
-		err := d.Run(updates)
+		err := d.Run(run.updates)
 		if err != nil {
-			errCh <- err
+			run.errCh <- err
 		}
-		close(errCh)
-		close(updates)
+		close(run.errCh)
+		close(run.updates)
 	}()
 
-	view := ""
-	solution := ""
+	return run
+}
 
-	// consume updates and feed Bubble Tea
-	go func() {
-		for u := range updates {
-			p.Send(tui.UpdateViewport(u.View, len(u.View)))
-			p.Send(tui.UpdateSolution(u.Solution))
+// bindings wraps any day specific key bindings for the tui
+func (r *visualRun) bindings(send func(tea.Msg) tea.Msg) []tui.Binding {
+	kb, ok := r.d.(KeyBinder)
+	if !ok {
+		return nil
+	}
 
-			if options.Delay != 0 {
-				time.Sleep(time.Millisecond * time.Duration(options.Delay))
-			}
+	var bindings []tui.Binding
+	for _, b := range kb.KeyBindings() {
+		bindings = append(bindings, tui.Binding{
+			Key: b.Binding,
+			Action: func() tea.Msg {
+				b.Handle()
+				if !r.finished.Load() {
+					// the next update will show the change
+					return nil
+				}
+				view := kb.View()
+				return send(tui.UpdateViewport(view, len(view)))
+			},
+		})
+	}
+	return bindings
+}
 
-			view = u.View
-			solution = u.Solution
+// feed consumes updates and feeds Bubble Tea
+func (r *visualRun) feed(p *tea.Program, playback *tui.Playback, send func(tea.Msg) tea.Msg) {
+	for u := range r.updates {
+		p.Send(send(tui.UpdateViewport(u.View, len(u.View))))
+		p.Send(send(tui.UpdateSolution(u.Solution)))
+		p.Send(send(tui.UpdateAnswer(u.Answer.Part1, u.Answer.Part2)))
 
-			if u.Done {
-			}
-		}
-	}()
+		r.mu.Lock()
+		r.last = u
+		r.mu.Unlock()
 
-	if _, err := p.Run(); err != nil {
-		return fmt.Errorf("could not start program: %v", err)
+		playback.Wait()
 	}
+	r.finished.Store(true)
+}
 
-	fmt.Printf("%s\n%s\n", view, solution)
+func (r *visualRun) lastUpdate() DayUpdate {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.last
+}
 
+// err returns the day's error, if it has finished with one
+func (r *visualRun) err() error {
 	select {
-	case err := <-errCh:
-		if err != nil {
-			return err
-		}
+	case err := <-r.errCh:
+		return err
 	default:
+		return nil
 	}
-
-	return nil
 }
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// Config holds user settings loaded from the config file
+type Config struct {
+	Theme string `json:"theme,omitempty"`
+
+	// Session is the session cookie from a logged in browser, fetch uses it to download inputs. The AOC_SESSION
+	// env var overrides it.
+	Session string `json:"session,omitempty"`
+	// BaseURL is where fetch downloads inputs from
+	BaseURL string `json:"baseUrl,omitempty"`
+}
+
+var (
+	configFile string
+	config     Config
+)
+
+// defaultConfigFile returns the config file in the user's config dir, i.e. ~/.config/advent-of-code-2025/config.json
+func defaultConfigFile() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "advent-of-code-2025", "config.json")
+}
+
+// loadConfig reads the config file, if it exists
+func loadConfig(filename string) (Config, error) {
+	var c Config
+	if filename == "" {
+		return c, nil
+	}
+	content, err := os.ReadFile(filename)
+	if errors.Is(err, fs.ErrNotExist) {
+		// no config is fine
+		return c, nil
+	}
+	if err != nil {
+		return c, fmt.Errorf("failed to read config %s %w", filename, err)
+	}
+
+	if err := json.Unmarshal(content, &c); err != nil {
+		return c, fmt.Errorf("failed to parse config %s %w", filename, err)
+	}
+	return c, nil
+}
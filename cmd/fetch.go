@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/sirgwain/advent-of-code-2025/advent/fetch"
+	"github.com/spf13/cobra"
+)
+
+// sessionEnv overrides the session in the config file
+const sessionEnv = "AOC_SESSION"
+
+func newFetchCmd() *cobra.Command {
+	var day int
+	var dir string
+	var baseURL string
+	cmd := &cobra.Command{
+		Use:   "fetch",
+		Short: "download a day's input",
+		Long: fmt.Sprintf(`download the puzzle input for a day into the input dir, i.e. inputs/day5.txt
+
+Inputs that have already been downloaded are never downloaded again. Downloading needs
+the session cookie from a logged in browser, in the %s env var or the session
+setting in the config file.`, sessionEnv),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			session := os.Getenv(sessionEnv)
+			if session == "" {
+				session = config.Session
+			}
+			// the flag wins over the config file
+			if !cmd.Flags().Changed("base-url") && config.BaseURL != "" {
+				baseURL = config.BaseURL
+			}
+
+			client := fetch.New(session, fetch.WithBaseURL(baseURL), fetch.WithDir(dir))
+			path, cached, err := client.Fetch(cmd.Context(), day)
+			if errors.Is(err, fetch.ErrNoSession) {
+				return fmt.Errorf("%w, set %s or session in %s", err, sessionEnv, configFile)
+			}
+			if err != nil {
+				return err
+			}
+
+			if cached {
+				fmt.Printf("day %d is already in %s\n", day, path)
+			} else {
+				fmt.Printf("saved day %d to %s\n", day, path)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVarP(&day, "day", "d", 0, "the day to download")
+	cmd.Flags().StringVar(&dir, "dir", fetch.DefaultDir, "the dir inputs are saved to")
+	cmd.Flags().StringVar(&baseURL, "base-url", fetch.DefaultBaseURL, "the server to download from")
+
+	cmd.MarkFlagRequired("day")
+
+	return cmd
+}
+
+func init() {
+	rootCmd.AddCommand(newFetchCmd())
+}
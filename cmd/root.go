@@ -1,11 +1,13 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
 	"path/filepath"
 
+	"github.com/sirgwain/advent-of-code-2025/advent"
 	"github.com/spf13/cobra"
 )
 
@@ -33,11 +35,21 @@ func logPreRun(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func rootPreRun(cmd *cobra.Command, args []string) (err error) {
+	if err := logPreRun(cmd, args); err != nil {
+		return err
+	}
+	config, err = loadConfig(configFile)
+	return err
+}
+
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
 	Use:               "advent-of-code-2025",
 	Short:             "advent-of-code solutions for 2025",
-	PersistentPreRunE: logPreRun,
+	PersistentPreRunE: rootPreRun,
+	// Execute prints errors itself, with more detail for bad input
+	SilenceErrors: true,
 	Run: func(cmd *cobra.Command, args []string) {
 		// Show usage
 		cmd.Help()
@@ -45,17 +57,33 @@ var rootCmd = &cobra.Command{
 	},
 }
 
+// exit codes, bad input gets its own so scripts can tell it apart from other failures
+const (
+	exitError    = 1
+	exitBadInput = 65 // EX_DATAERR from sysexits.h
+)
+
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
 	err := rootCmd.Execute()
-	if err != nil {
-		println(err)
-		os.Exit(1)
+	if err == nil {
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	var perr *advent.ParseError
+	if errors.As(err, &perr) {
+		if caret := perr.Caret(); caret != "" {
+			fmt.Fprintf(os.Stderr, "\n%s\n", caret)
+		}
+		os.Exit(exitBadInput)
 	}
+	os.Exit(exitError)
 }
 
 func init() {
 	// all commands have debug mode
 	rootCmd.PersistentFlags().StringVarP(&logFile, "log", "", "tmp/advent.log", "log file to send structured logs to")
+	rootCmd.PersistentFlags().StringVar(&configFile, "config", defaultConfigFile(), "config file to load settings from")
 }
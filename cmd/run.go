@@ -2,6 +2,8 @@ package cmd
 
 import (
 	"fmt"
+	"maps"
+	"strings"
 	"time"
 
 	"github.com/sirgwain/advent-of-code-2025/advent"
@@ -14,71 +16,114 @@ func newRunCmd() *cobra.Command {
 	var visualization bool
 	var quiet bool
 	var delay int
+	var themeName string
+	var copyAnswers bool
+	var compare string
+	var paramFlags []string
+	var compareParamFlags []string
 	cmd := &cobra.Command{
 		Use:   "run",
 		Short: "run a day",
 		Long:  `run the solution for a day`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			var d advent.Day
-			switch day {
-			case 0:
-				d = &advent.DayN{}
-			case 1:
-				d = &advent.Day1{}
-			case 2:
-				d = &advent.Day2{}
-			case 3:
-				d = &advent.Day3{}
-			case 4:
-				d = &advent.Day4{}
-			case 5:
-				d = &advent.Day5{}
-			case 6:
-				d = &advent.Day6{}
-			case 7:
-				d = &advent.Day7{}
-			case 8:
-				d = &advent.Day8{}
-			case 9:
-				d = &advent.Day9{}
-			case 10:
-				d = &advent.Day10{}
-			case 11:
-				d = &advent.Day11{}
-			case 12:
-				d = &advent.Day12{}
+			// the flags are fine by now, don't follow errors with the usage
+			cmd.SilenceUsage = true
+
+			d, err := advent.NewDay(day)
+			if err != nil {
+				return err
+			}
+
+			// the flag wins over the config file
+			if !cmd.Flags().Changed("theme") && config.Theme != "" {
+				themeName = config.Theme
+			}
+			theme, err := advent.ThemeByName(themeName)
+			if err != nil {
+				return err
+			}
+
+			params, err := parseParams(paramFlags)
+			if err != nil {
+				return err
 			}
 
-			if d == nil {
-				return fmt.Errorf("day %d not found", day)
+			// run a second copy of the day side by side, on another input or with other params
+			if compare != "" || len(compareParamFlags) > 0 {
+				if !visualization {
+					return fmt.Errorf("--compare and --compare-param require --visualization")
+				}
+				if compare == "" {
+					if input == "-" {
+						return fmt.Errorf("stdin can only be read once, use --compare to give the second pane a file")
+					}
+					compare = input
+				}
+				compareParams, err := parseParams(compareParamFlags)
+				if err != nil {
+					return err
+				}
+				// the second pane's params are layered over the first's
+				rightParams := maps.Clone(params)
+				maps.Copy(rightParams, compareParams)
+
+				other, err := advent.NewDay(day)
+				if err != nil {
+					return err
+				}
+				return advent.RunCompare(
+					advent.ComparePane{Day: d, Filename: input},
+					advent.ComparePane{Day: other, Filename: compare, Options: []advent.Option{advent.WithParams(rightParams)}},
+					advent.WithDelay(delay), advent.WithTheme(theme), advent.WithParams(params),
+				)
 			}
 
 			// run the visualizer if specified
 			if visualization {
-				return advent.RunVisual(d, input, advent.WithDelay(delay))
+				return advent.RunVisual(d, input, advent.WithDelay(delay), advent.WithTheme(theme), advent.WithParams(params))
 			}
 
 			start := time.Now()
 			defer func() { fmt.Printf("\nTime taken %v\n", time.Since(start)) }()
 
-			return advent.Run(d, input, advent.WithQuiet(quiet))
+			return advent.Run(d, input, advent.WithQuiet(quiet), advent.WithTheme(theme), advent.WithCopy(copyAnswers), advent.WithParams(params))
 		},
 	}
 
 	cmd.Flags().IntVarP(&day, "day", "d", 0, "the part to run, a or b")
-	cmd.Flags().StringVarP(&input, "input", "i", "", "the input file to load")
+	cmd.Flags().StringVarP(&input, "input", "i", "", "the input file to load, or - for stdin")
 	cmd.Flags().IntVar(&delay, "delay", 0, "a delay, in ms to add to the UI")
 	cmd.Flags().BoolVarP(&visualization, "visualization", "v", false, "run the visualization for this day, if available")
 	cmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "run in quiet mode")
+	cmd.Flags().StringVar(&compare, "compare", "", "a second input file to run side by side with the first, requires --visualization")
+	cmd.Flags().StringArrayVar(&compareParamFlags, "compare-param", nil, "a param as key=value for the second pane only, i.e. to compare implementations, can be repeated")
+	cmd.Flags().BoolVar(&copyAnswers, "copy", false, "copy the answers to the clipboard when done")
+	cmd.Flags().StringArrayVar(&paramFlags, "param", nil, "a day specific setting as key=value, can be repeated")
+	cmd.Flags().StringVar(&themeName, "theme", advent.ThemeDark, fmt.Sprintf("the color theme, one of %s", strings.Join(advent.ThemeNames(), ", ")))
 
 	cmd.MarkFlagRequired("day")
 	cmd.MarkFlagRequired("input")
 	// no quiet mode when visualizing
 	cmd.MarkFlagsMutuallyExclusive("quiet", "visualization")
+	// the visualization has its own copy key
+	cmd.MarkFlagsMutuallyExclusive("copy", "visualization")
 
 	return cmd
 }
 
+// parseParams splits key=value flags into a map
+func parseParams(flags []string) (map[string]string, error) {
+	params := make(map[string]string, len(flags))
+	for _, flag := range flags {
+		key, value, ok := strings.Cut(flag, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid param %q, expected key=value", flag)
+		}
+		params[key] = value
+	}
+	return params, nil
+}
+
 func init() {
 	rootCmd.AddCommand(newRunCmd())
 }
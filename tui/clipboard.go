@@ -0,0 +1,54 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aymanbagabas/go-osc52/v2"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// how long the copied message stays in the footer
+const flashDuration = 2 * time.Second
+
+type (
+	copiedMsg struct {
+		err error
+	}
+	clearFlashMsg struct {
+		id int
+	}
+)
+
+// CopyAnswers copies both answers, one per line, to the clipboard with an OSC52 escape sequence.
+// This goes through the terminal so it works over ssh as well.
+func CopyAnswers(w io.Writer, part1, part2 int64) error {
+	seq := osc52.New(fmt.Sprintf("%d\n%d", part1, part2))
+	if os.Getenv("TMUX") != "" {
+		seq = seq.Tmux()
+	} else if strings.HasPrefix(os.Getenv("TERM"), "screen") {
+		seq = seq.Screen()
+	}
+	if _, err := seq.WriteTo(w); err != nil {
+		return fmt.Errorf("failed to copy answers %w", err)
+	}
+	return nil
+}
+
+// copyAnswers copies the answers to the clipboard. Bubble Tea owns stdout so write the
+// sequence to stderr, which is the same terminal.
+func copyAnswers(part1, part2 int64) tea.Cmd {
+	return func() tea.Msg {
+		return copiedMsg{err: CopyAnswers(os.Stderr, part1, part2)}
+	}
+}
+
+// clearFlash clears the footer flash message after a delay
+func clearFlash(id int) tea.Cmd {
+	return tea.Tick(flashDuration, func(time.Time) tea.Msg {
+		return clearFlashMsg{id: id}
+	})
+}
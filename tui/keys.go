@@ -0,0 +1,83 @@
+package tui
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Binding is a day specific key binding. Action is run when the key is pressed and
+// may return a message to update the model with, i.e. UpdateViewport
+type Binding struct {
+	Key    key.Binding
+	Action func() tea.Msg
+}
+
+// KeyMap is the set of keys the tui responds to. It implements help.KeyMap
+type KeyMap struct {
+	Quit     key.Binding
+	Help     key.Binding
+	Copy     key.Binding
+	Pause    key.Binding
+	Faster   key.Binding
+	Slower   key.Binding
+	Viewport viewport.KeyMap
+	Day      []Binding
+}
+
+// DefaultKeyMap returns the keys every visualization supports
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		Quit: key.NewBinding(
+			key.WithKeys("q", "esc", "ctrl+c"),
+			key.WithHelp("q", "quit"),
+		),
+		Help: key.NewBinding(
+			key.WithKeys("?"),
+			key.WithHelp("?", "toggle help"),
+		),
+		Copy: key.NewBinding(
+			key.WithKeys("y"),
+			key.WithHelp("y", "copy answers"),
+		),
+		Pause: key.NewBinding(
+			key.WithKeys("p"),
+			key.WithHelp("p", "pause/resume"),
+		),
+		Faster: key.NewBinding(
+			key.WithKeys("+", "="),
+			key.WithHelp("+", "faster"),
+		),
+		Slower: key.NewBinding(
+			key.WithKeys("-"),
+			key.WithHelp("-", "slower"),
+		),
+		Viewport: viewport.DefaultKeyMap(),
+	}
+}
+
+func (k KeyMap) dayBindings() []key.Binding {
+	bindings := make([]key.Binding, len(k.Day))
+	for i, b := range k.Day {
+		bindings[i] = b.Key
+	}
+	return bindings
+}
+
+// ShortHelp shows the day's bindings along with help and quit
+func (k KeyMap) ShortHelp() []key.Binding {
+	return append(k.dayBindings(), k.Pause, k.Copy, k.Help, k.Quit)
+}
+
+// FullHelp shows every binding, grouped in columns
+func (k KeyMap) FullHelp() [][]key.Binding {
+	groups := [][]key.Binding{
+		{k.Viewport.Up, k.Viewport.Down, k.Viewport.PageUp, k.Viewport.PageDown},
+		{k.Viewport.HalfPageUp, k.Viewport.HalfPageDown, k.Viewport.Left, k.Viewport.Right},
+	}
+	groups = append(groups, []key.Binding{k.Pause, k.Faster, k.Slower})
+	if len(k.Day) > 0 {
+		groups = append(groups, k.dayBindings())
+	}
+	return append(groups, []key.Binding{k.Copy, k.Help, k.Quit})
+}
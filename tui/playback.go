@@ -0,0 +1,88 @@
+package tui
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	minDelay = 5 * time.Millisecond
+	maxDelay = 5 * time.Second
+)
+
+// Playback paces the updates fed to the ui. It is shared between the ui and
+// the goroutines sending updates so every pane pauses and changes speed together.
+type Playback struct {
+	mu      sync.Mutex
+	resumed *sync.Cond
+	paused  bool
+	stopped bool
+	delay   time.Duration
+}
+
+func NewPlayback(delay time.Duration) *Playback {
+	p := &Playback{delay: delay}
+	p.resumed = sync.NewCond(&p.mu)
+	return p
+}
+
+// Wait blocks while playback is paused and then waits out the delay between updates
+func (p *Playback) Wait() {
+	p.mu.Lock()
+	for p.paused && !p.stopped {
+		p.resumed.Wait()
+	}
+	delay := p.delay
+	if p.stopped {
+		delay = 0
+	}
+	p.mu.Unlock()
+
+	if delay != 0 {
+		time.Sleep(delay)
+	}
+}
+
+// TogglePause pauses or resumes playback
+func (p *Playback) TogglePause() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.paused = !p.paused
+	p.resumed.Broadcast()
+}
+
+// Faster halves the delay between updates
+func (p *Playback) Faster() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.delay /= 2
+	if p.delay < minDelay {
+		p.delay = 0
+	}
+}
+
+// Slower doubles the delay between updates
+func (p *Playback) Slower() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.delay = min(maxDelay, max(minDelay, p.delay*2))
+}
+
+// Stop releases anything waiting on playback, i.e. when the ui quits while paused
+func (p *Playback) Stop() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.stopped = true
+	p.resumed.Broadcast()
+}
+
+// String describes the playback state for the footer
+func (p *Playback) String() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.paused {
+		return "paused"
+	}
+	return fmt.Sprintf("delay %v", p.delay)
+}
@@ -0,0 +1,78 @@
+package tui
+
+import (
+	"slices"
+
+	"github.com/sirgwain/advent-of-code-2025/advent/braille"
+)
+
+const (
+	// keep history bounded for long runs, older samples are thinned out
+	maxHistory = 4096
+	// rows of braille characters per sparkline
+	sparklineHeight = 2
+)
+
+// history records an answer over time
+type history []int64
+
+// record appends a value, halving the resolution of the history when it gets too long
+func (h history) record(v int64) history {
+	if len(h) >= maxHistory {
+		thinned := h[:0]
+		for i := 0; i < len(h); i += 2 {
+			thinned = append(thinned, h[i])
+		}
+		h = thinned
+	}
+	return append(h, v)
+}
+
+// changed is true once the answer has had more than one value
+func (h history) changed() bool {
+	return len(h) > 1 && slices.Min(h) != slices.Max(h)
+}
+
+// sparkline renders the history as a braille line chart, width characters wide
+func (h history) sparkline(width int) string {
+	if len(h) == 0 || width <= 0 {
+		return ""
+	}
+
+	// braille cells are 2x4 pixels
+	pixelWidth := width * 2
+	pixelHeight := sparklineHeight * 4
+	grid := make([][]byte, pixelHeight)
+	for y := range grid {
+		grid[y] = make([]byte, pixelWidth)
+	}
+
+	low, high := slices.Min(h), slices.Max(h)
+	scale := func(v int64) int {
+		if high == low {
+			return pixelHeight - 1
+		}
+		// highest values are at the top of the chart
+		return pixelHeight - 1 - int(float64(v-low)/float64(high-low)*float64(pixelHeight-1))
+	}
+
+	prevY := -1
+	for x := range pixelWidth {
+		// sample the history for this column
+		i := x * len(h) / pixelWidth
+		if i >= len(h) {
+			break
+		}
+		y := scale(h[i])
+		if prevY == -1 {
+			prevY = y
+		}
+		// connect to the previous column so steps draw as lines
+		for fill := min(y, prevY); fill <= max(y, prevY); fill++ {
+			grid[fill][x] = 1
+		}
+		prevY = y
+	}
+
+	return braille.Render(grid)
+}
@@ -0,0 +1,163 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	// answers that match in both panes
+	sameAnswerStyle = lipgloss.NewStyle()
+	// answers that differ, reverse so it stands out in any theme
+	diffAnswerStyle = lipgloss.NewStyle().Reverse(true).Bold(true)
+)
+
+// SplitModel shows two runs side by side. Playback and scrolling are shared by both panes.
+type SplitModel struct {
+	panes    [2]Model
+	help     help.Model
+	keys     KeyMap
+	playback *Playback
+	width    int
+	height   int
+}
+
+// paneMsg routes a message to a single pane
+type paneMsg struct {
+	pane int
+	msg  tea.Msg
+}
+
+func NewSplitModel(left, right Model, playback *Playback) SplitModel {
+	keys := DefaultKeyMap()
+	// each pane has its own answers, so there is nothing obvious to copy
+	keys.Copy.SetEnabled(false)
+	// show the day bindings once, the panes run them
+	keys.Day = left.keys.Day
+
+	m := SplitModel{
+		panes:    [2]Model{left, right},
+		help:     help.New(),
+		keys:     keys,
+		playback: playback,
+	}
+	for i := range m.panes {
+		m.panes[i].embedded = true
+		m.panes[i].keys.Copy.SetEnabled(false)
+	}
+	return m
+}
+
+// ToPane wraps a message, like UpdateViewport, for one pane of a SplitModel
+func ToPane(pane int, msg tea.Msg) tea.Msg {
+	return paneMsg{pane: pane, msg: msg}
+}
+
+func NewSplitProgram(initialModel SplitModel) *tea.Program {
+	return tea.NewProgram(
+		initialModel,
+		tea.WithAltScreen(),
+		tea.WithMouseCellMotion(),
+	)
+}
+
+func (m SplitModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m SplitModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keys.Quit):
+			return m, tea.Quit
+		case key.Matches(msg, m.keys.Help):
+			m.help.ShowAll = !m.help.ShowAll
+			// the footer changed size, resize the panes to fit
+			return m.updatePanes(m.paneSize())
+		case key.Matches(msg, m.keys.Pause):
+			m.playback.TogglePause()
+			return m, nil
+		case key.Matches(msg, m.keys.Faster):
+			m.playback.Faster()
+			return m, nil
+		case key.Matches(msg, m.keys.Slower):
+			m.playback.Slower()
+			return m, nil
+		}
+
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.help.Width = msg.Width
+		return m.updatePanes(m.paneSize())
+
+	case paneMsg:
+		var cmd tea.Cmd
+		m.panes[msg.pane], cmd = m.updatePane(m.panes[msg.pane], msg.msg)
+		return m, cmd
+	}
+
+	// everything else, i.e. scrolling and day bindings, goes to both panes
+	return m.updatePanes(msg)
+}
+
+// paneSize splits the window between the two panes, leaving room for the footer
+func (m SplitModel) paneSize() tea.WindowSizeMsg {
+	footerHeight := lipgloss.Height(m.footerView())
+	// each pane draws its margins around the width it is given
+	return tea.WindowSizeMsg{
+		Width:  max(0, m.width/2-frameWidth()),
+		Height: max(0, m.height-footerHeight),
+	}
+}
+
+func (m SplitModel) updatePane(pane Model, msg tea.Msg) (Model, tea.Cmd) {
+	updated, cmd := pane.Update(msg)
+	return updated.(Model), cmd
+}
+
+func (m SplitModel) updatePanes(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmds []tea.Cmd
+	for i := range m.panes {
+		var cmd tea.Cmd
+		m.panes[i], cmd = m.updatePane(m.panes[i], msg)
+		cmds = append(cmds, cmd)
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// diffView compares the current answers of both panes, highlighting any that differ
+func (m SplitModel) diffView() string {
+	left, right := m.panes[0], m.panes[1]
+	return fmt.Sprintf("part1: %s  part2: %s",
+		diffAnswer(left.part1, right.part1),
+		diffAnswer(left.part2, right.part2),
+	)
+}
+
+func diffAnswer(left, right int64) string {
+	if left == right {
+		return sameAnswerStyle.Render(strconv.FormatInt(left, 10))
+	}
+	return diffAnswerStyle.Render(fmt.Sprintf("%d vs %d", left, right))
+}
+
+func (m SplitModel) footerView() string {
+	return lipgloss.JoinVertical(lipgloss.Left,
+		m.diffView(),
+		lipgloss.JoinHorizontal(lipgloss.Top, m.help.View(m.keys), "  ", m.playback.String()),
+	)
+}
+
+func (m SplitModel) View() string {
+	// the panes have their own margins
+	return lipgloss.JoinVertical(lipgloss.Left,
+		lipgloss.JoinHorizontal(lipgloss.Top, m.panes[0].View(), m.panes[1].View()),
+		mainStyle.Render(m.footerView()),
+	)
+}
@@ -0,0 +1,150 @@
+package tui_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sirgwain/advent-of-code-2025/tui"
+)
+
+func newSplit(playback *tui.Playback) tui.SplitModel {
+	return tui.NewSplitModel(tui.NewModel("left"), tui.NewModel("right"), playback)
+}
+
+// update sends msgs to a split model in order
+func update(m tui.SplitModel, msgs ...tea.Msg) tui.SplitModel {
+	for _, msg := range msgs {
+		updated, _ := m.Update(msg)
+		m = updated.(tui.SplitModel)
+	}
+	return m
+}
+
+func keyMsg(key string) tea.Msg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+}
+
+// rows is content of n numbered rows
+func rows(n int) string {
+	lines := make([]string, n)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("row%02d", i)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestSplitModel_Size(t *testing.T) {
+	for _, width := range []int{60, 80, 121} {
+		t.Run(fmt.Sprintf("width %d", width), func(t *testing.T) {
+			content := strings.Repeat("x", width)
+			m := update(newSplit(tui.NewPlayback(0)),
+				tea.WindowSizeMsg{Width: width, Height: 24},
+				tui.ToPane(0, tui.UpdateViewport(content, len(content))),
+				tui.ToPane(1, tui.UpdateViewport(content, len(content))),
+			)
+
+			view := m.View()
+			lines := strings.Split(view, "\n")
+			if len(lines) > 24 {
+				t.Errorf("View() is %d lines, want at most 24", len(lines))
+			}
+			for i, line := range lines {
+				if w := lipgloss.Width(line); w > width {
+					t.Errorf("View() line %d is %d wide, want at most %d: %q", i, w, width, line)
+				}
+			}
+			// the panes sit next to each other
+			if !strings.Contains(lines[1], "left") || !strings.Contains(lines[1], "right") {
+				t.Errorf("View() titles = %q, want both panes side by side", lines[1])
+			}
+		})
+	}
+}
+
+func TestSplitModel_ScrollsBothPanes(t *testing.T) {
+	m := update(newSplit(tui.NewPlayback(0)),
+		tea.WindowSizeMsg{Width: 80, Height: 20},
+		tui.ToPane(0, tui.UpdateViewport(rows(50), 5)),
+		tui.ToPane(1, tui.UpdateViewport(rows(50), 5)),
+	)
+
+	// new content follows the bottom
+	if got := strings.Count(m.View(), "row49"); got != 2 {
+		t.Fatalf("View() shows row49 %d times, want once in each pane", got)
+	}
+	m = update(m, tea.KeyMsg{Type: tea.KeyUp})
+	if got := strings.Count(m.View(), "row49"); got != 0 {
+		t.Errorf("View() shows row49 %d times after scrolling up, want both panes scrolled", got)
+	}
+}
+
+func TestSplitModel_AnswerDiff(t *testing.T) {
+	m := update(newSplit(tui.NewPlayback(0)),
+		tea.WindowSizeMsg{Width: 80, Height: 20},
+		tui.ToPane(0, tui.UpdateAnswer(7, 33)),
+		tui.ToPane(1, tui.UpdateAnswer(7, 40)),
+	)
+	view := m.View()
+	if !strings.Contains(view, "part1: 7 ") {
+		t.Errorf("View() = %q, want the matching part1 shown once", view)
+	}
+	if !strings.Contains(view, "part2: 33 vs 40") {
+		t.Errorf("View() = %q, want the differing part2 answers", view)
+	}
+
+	// answers are routed to their own pane, so catching up clears the diff
+	m = update(m, tui.ToPane(0, tui.UpdateAnswer(7, 40)))
+	if view := m.View(); strings.Contains(view, " vs ") {
+		t.Errorf("View() = %q, want no diff once the answers match", view)
+	}
+}
+
+func TestSplitModel_Playback(t *testing.T) {
+	playback := tui.NewPlayback(40 * time.Millisecond)
+	defer playback.Stop()
+	m := update(newSplit(playback), tea.WindowSizeMsg{Width: 80, Height: 20})
+
+	m = update(m, keyMsg("+"))
+	if got := playback.String(); got != "delay 20ms" {
+		t.Errorf("after + playback = %s, want delay 20ms", got)
+	}
+	m = update(m, keyMsg("-"), keyMsg("-"))
+	if got := playback.String(); got != "delay 80ms" {
+		t.Errorf("after - - playback = %s, want delay 80ms", got)
+	}
+	m = update(m, keyMsg("+"), keyMsg("+"), keyMsg("+"), keyMsg("+"), keyMsg("+"), keyMsg("+"), keyMsg("+"))
+	if got := playback.String(); got != "delay 0s" {
+		t.Errorf("after + x7 playback = %s, want delay 0s", got)
+	}
+
+	// pausing holds the feeds of both panes until playback resumes
+	m = update(m, keyMsg("p"))
+	if !strings.Contains(m.View(), "paused") {
+		t.Errorf("View() doesn't show playback is paused")
+	}
+	fed := make(chan int, 2)
+	for pane := range 2 {
+		go func() {
+			playback.Wait()
+			fed <- pane
+		}()
+	}
+	select {
+	case pane := <-fed:
+		t.Fatalf("pane %d was fed while paused", pane)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	update(m, keyMsg("p"))
+	for range 2 {
+		select {
+		case <-fed:
+		case <-time.After(time.Second):
+			t.Fatalf("panes weren't fed after resuming")
+		}
+	}
+}
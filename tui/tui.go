@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -12,28 +14,35 @@ import (
 
 var (
 	// base styles
-	titleStyle = func() lipgloss.Style {
-		b := lipgloss.RoundedBorder()
-		b.Right = "├"
-		return lipgloss.NewStyle().BorderStyle(b).Padding(0, 1)
-	}()
-
 	mainStyle     = lipgloss.NewStyle().MarginLeft(2)
 	viewportStyle = lipgloss.NewStyle().MarginLeft(2).MarginRight(2)
-	solutionStyle = lipgloss.NewStyle().
-			Padding(0, 1).
-			BorderStyle(lipgloss.NormalBorder()).
-			BorderForeground(color.CornflowerBlue63)
 )
 
+// frameWidth is how much wider a Model draws than the width it is sized to. The viewport's margins count
+// twice, content updates widen the viewport by them before they are drawn around it.
+func frameWidth() int {
+	return mainStyle.GetHorizontalFrameSize() + 2*viewportStyle.GetHorizontalFrameSize()
+}
+
 type Model struct {
 	ready        bool
 	viewport     viewport.Model
+	help         help.Model
+	keys         KeyMap
 	solution     string
+	part1        int64
+	part2        int64
+	history      [2]history // answers over time, for the sparklines
+	flash        string     // a short lived message in the footer
+	flashID      int
 	title        string
 	minWidth     int
 	windowWidth  int
 	windowHeight int
+	accent       lipgloss.TerminalColor
+	ascii        bool
+	playback     *Playback
+	embedded     bool // a pane in a split layout, the split renders the help
 }
 
 // custom messages
@@ -46,10 +55,45 @@ type (
 	updateSolutionMsg struct {
 		solution string
 	}
+	updateAnswerMsg struct {
+		part1 int64
+		part2 int64
+	}
 )
 
 func NewModel(title string) Model {
-	return Model{title: title}
+	return Model{
+		title:  title,
+		accent: color.CornflowerBlue63,
+		help:   help.New(),
+		keys:   DefaultKeyMap(),
+	}
+}
+
+// WithPlayback lets the keyboard pause and change the speed of playback
+func (m Model) WithPlayback(playback *Playback) Model {
+	m.playback = playback
+	return m
+}
+
+// WithBindings adds day specific key bindings
+func (m Model) WithBindings(bindings ...Binding) Model {
+	m.keys.Day = append(m.keys.Day, bindings...)
+	return m
+}
+
+// WithAccent sets the border color of the solution box
+func (m Model) WithAccent(accent lipgloss.TerminalColor) Model {
+	if accent != nil {
+		m.accent = accent
+	}
+	return m
+}
+
+// WithASCII draws borders and lines with plain ASCII characters
+func (m Model) WithASCII(ascii bool) Model {
+	m.ascii = ascii
+	return m
 }
 
 func (m Model) WithMinWidth(minWidth int) Model {
@@ -73,19 +117,84 @@ func UpdateSolution(solution string) tea.Msg {
 	return updateSolutionMsg{solution: solution}
 }
 
+func (m Model) titleStyle() lipgloss.Style {
+	b := lipgloss.RoundedBorder()
+	b.Right = "├"
+	if m.ascii {
+		b = lipgloss.ASCIIBorder()
+	}
+	return lipgloss.NewStyle().BorderStyle(b).Padding(0, 1)
+}
+
+func (m Model) solutionStyle() lipgloss.Style {
+	b := lipgloss.NormalBorder()
+	if m.ascii {
+		b = lipgloss.ASCIIBorder()
+	}
+	return lipgloss.NewStyle().
+		Padding(0, 1).
+		BorderStyle(b).
+		BorderForeground(m.accent)
+}
+
+// line is a horizontal rule of width characters
+func (m Model) line(width int) string {
+	if m.ascii {
+		return strings.Repeat("-", max(0, width))
+	}
+	return strings.Repeat("─", max(0, width))
+}
+
+// UpdateAnswer sets the raw answers, used for copying them to the clipboard
+func UpdateAnswer(part1, part2 int64) tea.Msg {
+	return updateAnswerMsg{part1: part1, part2: part2}
+}
+
 func (m Model) headerView() string {
-	title := titleStyle.Render(m.title)
-	line := strings.Repeat("─", max(0, m.viewport.Width-lipgloss.Width(title)))
+	title := m.titleStyle().Render(m.title)
+	line := m.line(m.viewport.Width - lipgloss.Width(title))
 	return lipgloss.JoinHorizontal(lipgloss.Center, title, line)
 }
 
 func (m Model) footerView() string {
-	line := strings.Repeat("─", max(0, m.viewport.Width))
-	return lipgloss.JoinHorizontal(lipgloss.Center, line)
+	line := m.line(m.viewport.Width)
+	if m.embedded {
+		return line
+	}
+	help := m.help.View(m.keys)
+	if m.playback != nil {
+		help = lipgloss.JoinHorizontal(lipgloss.Top, help, "  ", m.playback.String())
+	}
+	if m.flash != "" {
+		help = lipgloss.JoinHorizontal(lipgloss.Top, help, "  ", lipgloss.NewStyle().Foreground(m.accent).Render(m.flash))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, line, help)
 }
 
 func (m Model) solutionView() string {
-	return solutionStyle.Render(m.solution)
+	return m.solutionStyle().Render(m.solution)
+}
+
+// chartView draws sparklines for answers that change over time
+func (m Model) chartView() string {
+	if m.ascii {
+		// braille needs unicode
+		return ""
+	}
+
+	labels := [2]string{"part1 ", "part2 "}
+	var charts []string
+	for i, h := range m.history {
+		if !h.changed() {
+			continue
+		}
+		chart := h.sparkline(m.viewport.Width - lipgloss.Width(labels[i]))
+		charts = append(charts, lipgloss.JoinHorizontal(lipgloss.Top,
+			labels[i],
+			lipgloss.NewStyle().Foreground(m.accent).Render(strings.TrimSuffix(chart, "\n")),
+		))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, charts...)
 }
 
 // default init, does nothing
@@ -99,33 +208,45 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		if k := msg.String(); k == "ctrl+c" || k == "q" || k == "esc" {
+		switch {
+		case key.Matches(msg, m.keys.Quit):
 			return m, tea.Quit
+		case key.Matches(msg, m.keys.Help):
+			m.help.ShowAll = !m.help.ShowAll
+			// the footer changed size
+			m.resize()
+			return m, nil
+		case key.Matches(msg, m.keys.Copy):
+			return m, copyAnswers(m.part1, m.part2)
+		case m.playback != nil && key.Matches(msg, m.keys.Pause):
+			m.playback.TogglePause()
+			return m, nil
+		case m.playback != nil && key.Matches(msg, m.keys.Faster):
+			m.playback.Faster()
+			return m, nil
+		case m.playback != nil && key.Matches(msg, m.keys.Slower):
+			m.playback.Slower()
+			return m, nil
+		}
+		for _, b := range m.keys.Day {
+			if key.Matches(msg, b.Key) && b.Action != nil {
+				cmds = append(cmds, b.Action)
+			}
 		}
 
 	case tea.WindowSizeMsg:
-		headerHeight := lipgloss.Height(m.headerView())
-		footerHeight := lipgloss.Height(m.footerView())
-		solutionHeight := lipgloss.Height(m.solutionView())
-		verticalMarginHeight := headerHeight + footerHeight + solutionHeight
-
 		m.windowWidth, m.windowHeight = msg.Width, msg.Height
 		if m.minWidth == 0 {
 			m.minWidth = m.windowWidth
 		}
+		m.help.Width = msg.Width
 
 		if !m.ready {
-			m.viewport = viewport.New(
-				msg.Width,
-				msg.Height-verticalMarginHeight, // no extra -1
-			)
-			// Render viewport one line below the header.
-			m.viewport.YPosition = headerHeight + 1
+			m.viewport = viewport.New(msg.Width, 0)
 			m.ready = true
-		} else {
-			m.viewport.Width = msg.Width
-			m.viewport.Height = msg.Height - verticalMarginHeight
 		}
+		m.viewport.Width = msg.Width
+		m.resize()
 
 	case updateViewportMsg:
 		if m.ready {
@@ -152,6 +273,27 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case updateSolutionMsg:
 		m.solution = msg.solution
+
+	case updateAnswerMsg:
+		m.part1, m.part2 = msg.part1, msg.part2
+		m.history[0] = m.history[0].record(msg.part1)
+		m.history[1] = m.history[1].record(msg.part2)
+		// the charts may have appeared
+		m.resize()
+
+	case copiedMsg:
+		m.flash = "copied answers"
+		if msg.err != nil {
+			m.flash = msg.err.Error()
+		}
+		m.flashID++
+		cmds = append(cmds, clearFlash(m.flashID))
+
+	case clearFlashMsg:
+		// ignore stale clears from an earlier copy
+		if msg.id == m.flashID {
+			m.flash = ""
+		}
 	}
 
 	var vcmd tea.Cmd
@@ -163,12 +305,34 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+// resize fits the viewport height between the header, solution and footer
+func (m *Model) resize() {
+	if !m.ready {
+		return
+	}
+	headerHeight := lipgloss.Height(m.headerView())
+	footerHeight := lipgloss.Height(m.footerView())
+	solutionHeight := lipgloss.Height(m.solutionView())
+	verticalMarginHeight := headerHeight + footerHeight + solutionHeight
+	if chart := m.chartView(); chart != "" {
+		verticalMarginHeight += lipgloss.Height(chart)
+	}
+
+	m.viewport.Height = max(0, m.windowHeight-verticalMarginHeight)
+	// Render viewport one line below the header.
+	m.viewport.YPosition = headerHeight + 1
+}
+
 // The main view renders the header, viewport and footer
 func (m Model) View() string {
+	solution := m.solutionView()
+	if chart := m.chartView(); chart != "" {
+		solution = fmt.Sprintf("%s\n%s", solution, chart)
+	}
 	return mainStyle.Render(fmt.Sprintf("%s\n%s\n%s\n%s",
 		m.headerView(),
 		viewportStyle.Render(m.viewport.View()),
-		m.solutionView(),
+		solution,
 		m.footerView(),
 	))
 }